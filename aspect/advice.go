@@ -2,8 +2,11 @@
 package aspect
 
 import (
+	"errors"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 // -------------------------------------------- Constants & Variables --------------------------------------------
@@ -30,7 +33,25 @@ type AdviceFunc func(c *Context) error
 type Advice struct {
 	Type     AdviceType
 	Handler  AdviceFunc
-	Priority int // Higher priority executes first (for same type).
+	Priority int    // Higher priority executes first (for same type).
+	Group    string // Group optionally tags the advice so it can be toggled with Registry.SetGroupEnabled.
+	Name     string // Name optionally identifies the advice, e.g. to suppress it for a single call with Suppress.
+
+	// Condition optionally decides per invocation whether the advice runs; nil always runs it.
+	Condition func(c *Context) bool
+
+	// Shadow runs the advice on a copy of the context and records what it would have done
+	// (see Context.ShadowDecisions) instead of applying it, e.g. to validate new caching logic
+	// against live traffic. Divergences are reported to the OnAdviceError handler.
+	Shadow bool
+
+	// Temporary flags advice as non-permanent, e.g. installed by a single test case, so it can be
+	// dropped with Registry.RemoveTemporary while permanent setup stays intact.
+	Temporary bool
+
+	id       AdviceID
+	boundary bool           // boundary advice runs even once the context is done, e.g. to replace it (see BudgetedTimeout).
+	fired    *atomic.Uint64 // fired counts the executions of the advice, shared by its copies (see AdviceUsage).
 }
 
 // AdviceChain manages a collection of advice for a single function.
@@ -40,6 +61,10 @@ type AdviceChain struct {
 	around         []Advice
 	afterReturning []Advice
 	afterThrowing  []Advice
+	parallelAfter  atomic.Bool
+	frozen         atomic.Bool // frozen chains are immutable and read without locking (see Freeze).
+	resolved       bool        // resolved chains already include global and pattern advice.
+	state          sync.Map    // state holds advice state (see SetState).
 	mu             sync.RWMutex
 }
 
@@ -57,10 +82,18 @@ func NewAdviceChain() *AdviceChain {
 // -------------------------------------------- Public Functions --------------------------------------------
 
 // Add adds advice to the chain based on its type.
-func (ac *AdviceChain) Add(advice Advice) {
+// Returns ErrChainFrozen if the chain is frozen.
+func (ac *AdviceChain) Add(advice Advice) error {
 	ac.mu.Lock()
 	defer ac.mu.Unlock()
 
+	if ac.frozen.Load() {
+		return ErrChainFrozen
+	}
+	if advice.fired == nil {
+		advice.fired = new(atomic.Uint64)
+	}
+
 	switch advice.Type {
 	case Before:
 		ac.before = append(ac.before, advice)
@@ -73,51 +106,107 @@ func (ac *AdviceChain) Add(advice Advice) {
 	case AfterThrowing:
 		ac.afterThrowing = append(ac.afterThrowing, advice)
 	}
+	return nil
 }
 
 // ExecuteBefore runs all Before advice in order of priority.
 func (ac *AdviceChain) ExecuteBefore(c *Context) error {
-	ac.mu.RLock()
-	advice := append([]Advice(nil), ac.before...)
-	ac.mu.RUnlock()
-
-	return ac.executeAdviceList(advice, c)
+	return ac.executeAdviceList(ac.snapshot(Before), c)
 }
 
 // ExecuteAfter runs all After advice in order of priority.
 func (ac *AdviceChain) ExecuteAfter(c *Context) error {
-	ac.mu.RLock()
-	advice := append([]Advice(nil), ac.after...)
-	ac.mu.RUnlock()
-
-	return ac.executeAdviceList(advice, c)
+	return ac.executeAdviceList(ac.snapshot(After), c)
 }
 
 // ExecuteAround runs all Around advice in order of priority.
 func (ac *AdviceChain) ExecuteAround(c *Context) error {
-	ac.mu.RLock()
-	advice := append([]Advice(nil), ac.around...)
-	ac.mu.RUnlock()
-
-	return ac.executeAdviceList(advice, c)
+	return ac.executeAdviceList(ac.snapshot(Around), c)
 }
 
 // ExecuteAfterReturning runs all AfterReturning advice in order of priority.
 func (ac *AdviceChain) ExecuteAfterReturning(c *Context) error {
-	ac.mu.RLock()
-	advice := append([]Advice(nil), ac.afterReturning...)
-	ac.mu.RUnlock()
-
-	return ac.executeAdviceList(advice, c)
+	return ac.executeAdviceList(ac.snapshot(AfterReturning), c)
 }
 
 // ExecuteAfterThrowing runs all AfterThrowing advice in order of priority.
 func (ac *AdviceChain) ExecuteAfterThrowing(c *Context) error {
-	ac.mu.RLock()
-	advice := append([]Advice(nil), ac.afterThrowing...)
-	ac.mu.RUnlock()
+	return ac.executeAdviceList(ac.snapshot(AfterThrowing), c)
+}
+
+// Merge appends all advice of other into the chain, e.g. to build a reusable bundle such as a
+// "security chain" once and attach it to several functions. Advice keeps its priority, so the
+// merged chain runs by priority as usual; at equal priority merged advice runs after the chain's
+// own. Both chains are locked in a consistent order, so concurrent merges cannot deadlock.
+// Returns ErrChainFrozen if the chain is frozen.
+func (ac *AdviceChain) Merge(other *AdviceChain) error {
+	if other == ac {
+		ac.mu.Lock()
+		defer ac.mu.Unlock()
+		if ac.frozen.Load() {
+			return ErrChainFrozen
+		}
+		ac.appendAll(ac)
+		return nil
+	}
+
+	first, second := ac, other
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if ac.frozen.Load() {
+		return ErrChainFrozen
+	}
+	ac.appendAll(other)
+	return nil
+}
+
+// Clear removes all advice from the chain.
+// Returns ErrChainFrozen if the chain is frozen.
+func (ac *AdviceChain) Clear() error {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
 
-	return ac.executeAdviceList(advice, c)
+	if ac.frozen.Load() {
+		return ErrChainFrozen
+	}
+
+	ac.before = make([]Advice, 0)
+	ac.after = make([]Advice, 0)
+	ac.around = make([]Advice, 0)
+	ac.afterReturning = make([]Advice, 0)
+	ac.afterThrowing = make([]Advice, 0)
+	return nil
+}
+
+// ClearType removes all advice of the given type from the chain.
+// Returns ErrChainFrozen if the chain is frozen.
+func (ac *AdviceChain) ClearType(adviceType AdviceType) error {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if ac.frozen.Load() {
+		return ErrChainFrozen
+	}
+
+	switch adviceType {
+	case Before:
+		ac.before = make([]Advice, 0)
+	case After:
+		ac.after = make([]Advice, 0)
+	case Around:
+		ac.around = make([]Advice, 0)
+	case AfterReturning:
+		ac.afterReturning = make([]Advice, 0)
+	case AfterThrowing:
+		ac.afterThrowing = make([]Advice, 0)
+	}
+	return nil
 }
 
 // HasAround returns true if the chain has Around advice.
@@ -130,8 +219,10 @@ func (ac *AdviceChain) HasAround() bool {
 
 // Count returns the total number of advice in the chain.
 func (ac *AdviceChain) Count() int {
-	ac.mu.RLock()
-	defer ac.mu.RUnlock()
+	if !ac.frozen.Load() {
+		ac.mu.RLock()
+		defer ac.mu.RUnlock()
+	}
 
 	return len(ac.before) +
 		len(ac.after) +
@@ -142,33 +233,200 @@ func (ac *AdviceChain) Count() int {
 
 // -------------------------------------------- Private Helper Functions --------------------------------------------
 
+// appendAll appends the advice of other to the chain. The caller must hold both locks.
+func (ac *AdviceChain) appendAll(other *AdviceChain) {
+	ac.before = append(ac.before, other.before...)
+	ac.after = append(ac.after, other.after...)
+	ac.around = append(ac.around, other.around...)
+	ac.afterReturning = append(ac.afterReturning, other.afterReturning...)
+	ac.afterThrowing = append(ac.afterThrowing, other.afterThrowing...)
+}
+
+// hasNamed reports whether the chain holds advice of the given type and name.
+func (ac *AdviceChain) hasNamed(adviceType AdviceType, name string) bool {
+	for _, advice := range ac.snapshot(adviceType) {
+		if advice.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot returns a copy of the advice of the given type. Frozen chains return their immutable
+// list as it is, which callers must not modify.
+func (ac *AdviceChain) snapshot(adviceType AdviceType) []Advice {
+	if ac.frozen.Load() {
+		return ac.list(adviceType)
+	}
+
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	return append([]Advice(nil), ac.list(adviceType)...)
+}
+
 // executeAdviceList runs a list of advice in priority order.
+// Advice with equal priority keeps its position in the list (stable ordering).
 func (ac *AdviceChain) executeAdviceList(adviceList []Advice, c *Context) error {
+	for _, advice := range orderByPriority(adviceList, c) {
+		if err := c.runAdvice(advice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeAroundList runs the Around advice from index i on, in priority order, nested like an
+// onion with the target innermost: Proceed in advice i runs the advice from i+1 on, so lower
+// priority Around advice runs within higher priority advice and the target runs once, where the
+// innermost advice proceeds. Advice returning without proceeding hands over to the advice from
+// i+1 on, and the target does not run once advice skipped it. An error of nested advice aborts
+// the invocation even if the advice proceeding to it ignores the error.
+func executeAroundList(around []Advice, i int, targetFn func(*Context), c *Context) error {
+	if i == len(around) {
+		if !c.Skipped {
+			c.targetRan = true
+			targetFn(c)
+		}
+		return nil
+	}
+
+	proceeded := false
+	var nestedErr error
+	outer := c.proceed
+	c.proceed = func() error {
+		proceeded = true
+		nestedErr = executeAroundList(around, i+1, targetFn, c)
+		return nestedErr
+	}
+	err := c.runAdvice(around[i])
+	c.proceed = outer
+
+	switch {
+	case err != nil:
+		return err
+	case nestedErr != nil:
+		return nestedErr
+	case !proceeded:
+		return executeAroundList(around, i+1, targetFn, c)
+	}
+	return nil
+}
+
+// executeAdviceParallel runs a list of advice concurrently, each on its own copy of the context,
+// and waits for all of them. Priority ordering is not honored.
+func (ac *AdviceChain) executeAdviceParallel(adviceList []Advice, c *Context) error {
 	if len(adviceList) == 0 {
 		return nil
 	}
+	if err := c.Context().Err(); err != nil && interruptible(adviceList[0].Type) {
+		return err
+	}
+
+	errs := make([]error, len(adviceList))
+	var wg sync.WaitGroup
+	for i, advice := range adviceList {
+		if !c.shouldRun(advice) {
+			continue
+		}
+		advice.markFired()
+		if advice.Shadow {
+			c.runShadow(advice)
+			continue
+		}
+		c.traceAdvice(advice)
+
+		wg.Add(1)
+		go func(i int, advice Advice, clone *Context) {
+			defer wg.Done()
+			defer func() {
+				// A panic on this goroutine cannot be recovered by the caller
+				if r := recover(); r != nil {
+					errs[i] = advicePanicError(advice.Type, r)
+				}
+			}()
+			errs[i] = advice.Handler(clone)
+		}(i, advice, c.Clone())
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// orderByPriority returns a list of advice in the order it runs, highest priority first.
+// Advice with equal priority keeps its position in the list (stable ordering).
+func orderByPriority(adviceList []Advice, c *Context) []Advice {
+	if len(adviceList) < 2 {
+		// Fast path for the common single-advice phase: nothing to order, so no copy and no sort
+		return adviceList
+	}
+
+	// Lists already in priority order, such as the pre-sorted lists of frozen chains, run as they are
+	overrides := c.priorityOverrides()
+	if overrides == nil && sortedByPriority(adviceList) {
+		return adviceList
+	}
 
 	// Sort by priority (highest first)
 	sortedAdviceList := make([]Advice, len(adviceList))
 	copy(sortedAdviceList, adviceList)
 
-	sort.Slice(sortedAdviceList, func(i, j int) bool {
-		return sortedAdviceList[i].Priority > sortedAdviceList[j].Priority
+	sort.SliceStable(sortedAdviceList, func(i, j int) bool {
+		return effectivePriority(sortedAdviceList[i], overrides) > effectivePriority(sortedAdviceList[j], overrides)
 	})
+	return sortedAdviceList
+}
 
-	// Execute in order
-	for _, advice := range sortedAdviceList {
-		// Check if context is cancelled before executing advice
+// runAdvice runs a single advice of the invocation unless the context is done or the advice is
+// skipped (see shouldRun). Shadow advice is only recorded (see runShadow).
+func (c *Context) runAdvice(advice Advice) error {
+	// Check if context is cancelled before executing advice; cleanup and boundary advice always run
+	if interruptible(advice.Type) && !advice.boundary {
 		select {
 		case <-c.Context().Done():
+			if advice.Type == Before && c.beforeSliceExpired() {
+				return nil // Skipped, so the target keeps its share of the budget (see BudgetedTimeout)
+			}
 			return c.Context().Err()
 		default:
 			// Context not cancelled, continue execution
 		}
+	}
 
-		if err := advice.Handler(c); err != nil {
-			return err
-		}
+	if !c.shouldRun(advice) {
+		return nil
 	}
-	return nil
+	advice.markFired()
+	if advice.Shadow {
+		c.runShadow(advice)
+		return nil
+	}
+
+	c.traceAdvice(advice)
+	return advice.Handler(c)
+}
+
+// shouldRun reports whether advice runs for the invocation: its group must be enabled,
+// it must not be suppressed through the context and its condition, if any, must hold. The
+// group counts the advice as fired only once all checks passed.
+func (c *Context) shouldRun(advice Advice) bool {
+	if c.registry != nil && !c.registry.groupEnabled(advice.Group) {
+		return false
+	}
+	if isSuppressed(c.Context(), advice.Name) {
+		return false
+	}
+	if advice.Condition != nil && !advice.Condition(c) {
+		return false
+	}
+	if c.registry != nil {
+		c.registry.groupFired(advice.Group)
+	}
+	return true
+}
+
+// interruptible reports whether advice of the given type is skipped once the context is done.
+// After and AfterThrowing advice perform cleanup and run regardless.
+func interruptible(adviceType AdviceType) bool {
+	return adviceType != After && adviceType != AfterThrowing
 }
@@ -3,6 +3,9 @@ package aspect
 
 import (
 	"errors"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -313,3 +316,193 @@ func TestAround_SkipExecution(t *testing.T) {
 
 	registry.Unregister("TestAround")
 }
+
+func TestAfterThrowing_RewritesPanicValue(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("TestSanitizePanic")
+
+	errInternal := errors.New("internal error")
+	registry.MustAddAdvice("TestSanitizePanic", Advice{
+		Type:     AfterThrowing,
+		Priority: 100,
+		Handler: func(c *Context) error {
+			c.PanicValue = errInternal
+			return nil
+		},
+	})
+
+	var observed any
+	registry.MustAddAdvice("TestSanitizePanic", Advice{
+		Type:     AfterThrowing,
+		Priority: 10,
+		Handler: func(c *Context) error {
+			observed = c.PanicValue
+			return nil
+		},
+	})
+
+	wrapped := Wrap0E(registry, "TestSanitizePanic", func() error {
+		panic("pq: password authentication failed for user admin")
+	})
+
+	err := wrapped()
+	if !errors.Is(err, errInternal) {
+		t.Fatalf("expected surfaced error to carry the sanitized panic value, got %v", err)
+	}
+	if strings.Contains(err.Error(), "password") {
+		t.Errorf("raw panic value leaked into the error: %v", err)
+	}
+	if observed != errInternal {
+		t.Errorf("expected later AfterThrowing advice to observe the sanitized value, got %v", observed)
+	}
+}
+
+func TestAdviceChain_ClearType(t *testing.T) {
+	chain := NewAdviceChain()
+	noop := func(c *Context) error { return nil }
+
+	chain.Add(Advice{Type: Before, Handler: noop})
+	chain.Add(Advice{Type: Before, Handler: noop})
+	chain.Add(Advice{Type: After, Handler: noop})
+	chain.Add(Advice{Type: Around, Handler: noop})
+
+	chain.ClearType(Before)
+	if chain.Count() != 2 {
+		t.Fatalf("expected 2 advice after clearing Before, got %d", chain.Count())
+	}
+	if !chain.HasAround() {
+		t.Error("expected Around advice to be kept")
+	}
+
+	chain.Clear()
+	if chain.Count() != 0 {
+		t.Fatalf("expected empty chain, got %d", chain.Count())
+	}
+}
+
+func TestAround_WarnSkipWithoutResult(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("TestSkipNoResult")
+
+	setResult := false
+	registry.MustAddAdvice("TestSkipNoResult", Advice{
+		Type: Around,
+		Handler: func(c *Context) error {
+			if setResult {
+				c.SetResult(0, 7)
+			}
+			c.Skipped = true
+			return nil
+		},
+	})
+
+	var warnings []error
+	registry.OnAdviceError(func(c *Context, err error) {
+		warnings = append(warnings, err)
+	})
+
+	wrapped := Wrap1R(registry, "TestSkipNoResult", func(x int) int { return x })
+
+	// Disabled by default
+	if got := wrapped(1); got != 0 {
+		t.Errorf("expected zero value, got %d", got)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warning when disabled, got %v", warnings)
+	}
+
+	registry.SetWarnSkipNoResult(true)
+	wrapped(1)
+	if len(warnings) != 1 || !errors.Is(warnings[0], ErrSkipWithoutResult) {
+		t.Fatalf("expected ErrSkipWithoutResult warning, got %v", warnings)
+	}
+
+	// A skip providing a result does not warn
+	setResult = true
+	if got := wrapped(1); got != 7 {
+		t.Errorf("expected result from advice, got %d", got)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected no additional warning, got %v", warnings)
+	}
+}
+
+func TestAdvice_Condition(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var audited []int
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:      Before,
+		Condition: func(c *Context) bool { return c.Args[0].(int) < 0 },
+		Handler: func(c *Context) error {
+			audited = append(audited, c.Args[0].(int))
+			return nil
+		},
+	})
+
+	getUser := Wrap1(registry, "GetUser", func(id int) {})
+	for _, id := range []int{1, -2, 3, -4} {
+		getUser(id)
+	}
+
+	if len(audited) != 2 || audited[0] != -2 || audited[1] != -4 {
+		t.Errorf("expected advice to run only when its condition holds, got %v", audited)
+	}
+}
+
+func TestAdviceChain_Merge(t *testing.T) {
+	var order []string
+	record := func(name string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	security := NewAdviceChain()
+	security.Add(Advice{Type: Before, Priority: 100, Handler: record("auth")})
+	security.Add(Advice{Type: Before, Priority: 10, Handler: record("audit")})
+	security.Add(Advice{Type: After, Priority: 5, Handler: record("audit-after")})
+
+	registry := NewRegistry()
+	for _, funcKey := range []FuncKey{"GetUser", "DeleteUser"} {
+		chain := registry.RegisterOrGet(funcKey)
+		chain.Add(Advice{Type: Before, Priority: 50, Handler: record("log")})
+		chain.Add(Advice{Type: After, Priority: 5, Handler: record("log-after")})
+		chain.Merge(security)
+	}
+
+	for _, funcKey := range []FuncKey{"GetUser", "DeleteUser"} {
+		order = nil
+		Wrap0(registry, funcKey, func() { order = append(order, "target") })()
+
+		expected := []string{"auth", "log", "audit", "target", "log-after", "audit-after"}
+		if !reflect.DeepEqual(order, expected) {
+			t.Errorf("%s: expected %v, got %v", funcKey, expected, order)
+		}
+	}
+
+	if security.Count() != 3 {
+		t.Errorf("expected the merged chain to be unchanged, got %d advice", security.Count())
+	}
+}
+
+func TestAdviceChain_MergeConcurrent(t *testing.T) {
+	a, b := NewAdviceChain(), NewAdviceChain()
+	a.Add(Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+	b.Add(Advice{Type: After, Handler: func(c *Context) error { return nil }})
+
+	var wg sync.WaitGroup
+	for range 5 { // Each merge grows the chains, keep the rounds few
+		wg.Add(2)
+		go func() { defer wg.Done(); a.Merge(b) }()
+		go func() { defer wg.Done(); b.Merge(a) }()
+	}
+	wg.Wait() // Opposite merges must not deadlock
+
+	a.Merge(a)
+	if a.Count() == 0 || a.Count()%2 != 0 {
+		t.Errorf("expected self merge to double the advice, got %d", a.Count())
+	}
+}
@@ -0,0 +1,68 @@
+// Package aspect - adviceerror provides the error type wrapping errors returned by advice
+package aspect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrAdvicePanic is the error of an AfterReturning, AfterThrowing or After advice handler that panicked.
+// The panic is recovered at the phase boundary so the rest of the lifecycle still runs.
+var ErrAdvicePanic = errors.New("advice panicked")
+
+// adviceErrorPrefixes are the phase names used in advice error messages.
+var adviceErrorPrefixes = map[AdviceType]string{
+	Before:         "before",
+	After:          "after",
+	Around:         "around",
+	AfterReturning: "afterReturning",
+	AfterThrowing:  "afterThrowing",
+}
+
+// -------------------------------------------- Types --------------------------------------------
+
+// AdviceError wraps an error returned by Before, Around or AfterReturning advice that aborted
+// an invocation. It unwraps to the handler's error, so errors.Is and errors.As see through it.
+type AdviceError struct {
+	Phase AdviceType // Phase is the type of the advice that failed.
+	Err   error      // Err is the error returned by the advice handler.
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Error implements the error interface.
+func (e *AdviceError) Error() string {
+	return adviceErrorPrefixes[e.Phase] + " advice failed: " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the advice handler.
+func (e *AdviceError) Unwrap() error {
+	return e.Err
+}
+
+// CauseError returns the error of the invocation with all advice error wrapping removed, i.e.
+// the error originally returned by the handler. Nested invocations wrap repeatedly, e.g. when
+// Around advice returns the error of another wrapped call, and all levels are removed.
+// Errors wrapped otherwise, such as recovered panics, are returned as they are.
+func (c *Context) CauseError() error {
+	err := c.Error
+	for {
+		if _, ok := err.(*AdviceError); !ok {
+			return err
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// advicePanicError converts a panic recovered from advice of the given phase into an error.
+// Panic values that are errors are wrapped so callers can match them with errors.Is and errors.As.
+func advicePanicError(phase AdviceType, value any) error {
+	if err, ok := value.(error); ok {
+		return fmt.Errorf("%w in %s advice: %w", ErrAdvicePanic, adviceErrorPrefixes[phase], err)
+	}
+	return fmt.Errorf("%w in %s advice: %v", ErrAdvicePanic, adviceErrorPrefixes[phase], value)
+}
@@ -0,0 +1,51 @@
+// Package aspect - adviceerror_test validates advice error wrapping and cause extraction
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_CauseError(t *testing.T) {
+	errDenied := errors.New("denied")
+
+	registry := NewRegistry()
+	registry.MustRegister("Authorize")
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("Authorize", Advice{Type: Before, Handler: func(c *Context) error {
+		return errDenied
+	}})
+
+	authorize := Wrap0ECtx(registry, "Authorize", func(ctx context.Context) error { return nil })
+	registry.MustAddAdvice("GetUser", Advice{Type: Around, Handler: func(c *Context) error {
+		return authorize(c.Context())
+	}})
+
+	c := registry.Execute(context.Background(), "GetUser", func(c *Context) {})
+
+	if c.Err().Error() != "around advice failed: before advice failed: denied" {
+		t.Errorf("unexpected error message: %v", c.Err())
+	}
+	if !errors.Is(c.Err(), errDenied) {
+		t.Error("expected errors.Is to see through advice errors")
+	}
+	var adviceErr *AdviceError
+	if !errors.As(c.Err(), &adviceErr) || adviceErr.Phase != Around {
+		t.Errorf("expected an Around AdviceError, got %#v", adviceErr)
+	}
+	if c.CauseError() != errDenied {
+		t.Errorf("expected the original handler error, got %v", c.CauseError())
+	}
+
+	// Errors not wrapped by advice are returned as they are
+	c = registry.Execute(context.Background(), "Plain", func(c *Context) { c.Error = errDenied })
+	if c.CauseError() != errDenied {
+		t.Errorf("expected the target error, got %v", c.CauseError())
+	}
+	if NewContext("test").CauseError() != nil {
+		t.Error("expected nil cause without error")
+	}
+}
@@ -0,0 +1,158 @@
+// Package aspect - args provides argument rewriting by advice
+package aspect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrArgType is reported when advice rewrites an argument with a value of an incompatible type
+// while strict argument rewriting is enabled.
+var ErrArgType = errors.New("argument rewritten with incompatible type")
+
+// -------------------------------------------- Types --------------------------------------------
+
+// lazyArgs defers formatting the arguments of a context until String is called.
+type lazyArgs struct {
+	c *Context
+}
+
+// String implements fmt.Stringer.
+func (l lazyArgs) String() string {
+	return l.c.ArgsString()
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetArg replaces the argument at the specified index.
+// The typed wrappers read arguments back from the context before invoking the target,
+// so Before and Around advice can rewrite the arguments the target receives.
+// Out of range indices are ignored.
+func (c *Context) SetArg(index int, value any) {
+	if index < 0 || index >= len(c.Args) {
+		return
+	}
+	c.Args[index] = value
+
+	c.mu.Lock()
+	c.argsString = nil
+	c.mu.Unlock()
+}
+
+// ArgsString returns the arguments formatted with %v. The string is computed on first use and
+// cached; SetArg invalidates the cache, direct writes to Args do not.
+func (c *Context) ArgsString() string {
+	c.mu.RLock()
+	cached := c.argsString
+	c.mu.RUnlock()
+	if cached != nil {
+		return *cached
+	}
+
+	formatted := fmt.Sprintf("%v", c.Args)
+
+	c.mu.Lock()
+	c.argsString = &formatted
+	c.mu.Unlock()
+	return formatted
+}
+
+// LazyArgs returns a fmt.Stringer rendering the arguments of c only when it is formatted.
+// Pass it to loggers instead of c.Args so filtered log calls never pay for formatting:
+//
+//	logger.Debug("calling", "func", c.FunctionName, "args", aspect.LazyArgs(c))
+func LazyArgs(c *Context) fmt.Stringer {
+	return lazyArgs{c: c}
+}
+
+// Arg returns the argument at the specified index, or nil if the index is out of range.
+// Prefer it over indexing Args in advice that only observes arguments: it cannot panic and
+// cannot accidentally rewrite the argument the target receives (use SetArg for that).
+func (c *Context) Arg(index int) any {
+	if index < 0 || index >= len(c.Args) {
+		return nil
+	}
+	return c.Args[index]
+}
+
+// ArgCount returns the number of arguments of the invocation.
+func (c *Context) ArgCount() int {
+	return len(c.Args)
+}
+
+// ArgType returns the dynamic type of the argument at the specified index,
+// or nil if the argument is nil or the index is out of range.
+// It lets function-agnostic advice inspect arguments without type assertions.
+func (c *Context) ArgType(index int) reflect.Type {
+	if index < 0 || index >= len(c.Args) {
+		return nil
+	}
+	return reflect.TypeOf(c.Args[index])
+}
+
+// SetStrictArgRewrite controls how the wrappers handle arguments rewritten with an incompatible type.
+// In lenient mode (default) the original argument is silently kept. In strict mode the call is
+// aborted with an ErrArgType error, which is also reported to the OnAdviceError handler.
+func (registry *Registry) SetStrictArgRewrite(strict bool) {
+	registry.strictArgs.Store(strict)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// argAt reads back the argument at index from the context, falling back to original if the
+// advice left a value of an incompatible type. It returns false if the call must be aborted.
+func argAt[T any](c *Context, index int, original T) (T, bool) {
+	if index >= len(c.Args) {
+		return original, true
+	}
+
+	value := c.Args[index]
+	if typed, ok := value.(T); ok {
+		return typed, true
+	}
+	if value == nil && isNillable(reflect.TypeFor[T]()) {
+		var zero T
+		return zero, true
+	}
+
+	if c.registry == nil || !c.registry.strictArgs.Load() {
+		return original, true
+	}
+
+	err := fmt.Errorf("%w: argument %d of '%s' is %T, expected %v", ErrArgType, index, c.FunctionName, value, reflect.TypeFor[T]())
+	c.Error = err
+	c.registry.reportAdviceError(c, err)
+	return original, false
+}
+
+// args1 reads back a single argument from the context.
+func args1[A any](c *Context, a A) (A, bool) {
+	return argAt(c, 0, a)
+}
+
+// args2 reads back two arguments from the context.
+func args2[A, B any](c *Context, a A, b B) (A, B, bool) {
+	a, okA := argAt(c, 0, a)
+	b, okB := argAt(c, 1, b)
+	return a, b, okA && okB
+}
+
+// args3 reads back three arguments from the context.
+func args3[A, B, C any](c *Context, a A, b B, paramC C) (A, B, C, bool) {
+	a, okA := argAt(c, 0, a)
+	b, okB := argAt(c, 1, b)
+	paramC, okC := argAt(c, 2, paramC)
+	return a, b, paramC, okA && okB && okC
+}
+
+// isNillable reports whether nil is a valid value for the given type.
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+// Package aspect - args_test validates argument rewriting by advice
+package aspect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_SetArg_RewritesTargetArgument(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Greet")
+	registry.MustAddAdvice("Greet", Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			c.SetArg(1, "bob")
+			c.SetArg(5, "ignored")
+			return nil
+		},
+	})
+
+	greet := Wrap2R(registry, "Greet", func(greeting, name string) string {
+		return greeting + " " + name
+	})
+
+	if got := greet("hello", "alice"); got != "hello bob" {
+		t.Errorf("expected rewritten argument, got %q", got)
+	}
+}
+
+func TestContext_SetArg_NilForPointer(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Deref")
+	registry.MustAddAdvice("Deref", Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			c.SetArg(0, nil)
+			return nil
+		},
+	})
+
+	value := 42
+	isNil := Wrap1R(registry, "Deref", func(p *int) bool { return p == nil })
+
+	if !isNil(&value) {
+		t.Error("expected nil argument to reach the target")
+	}
+}
+
+func TestRegistry_SetStrictArgRewrite(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Upper")
+	registry.MustAddAdvice("Upper", Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			c.SetArg(0, 42) // Wrong type: the target takes a string
+			return nil
+		},
+	})
+
+	var reported error
+	registry.OnAdviceError(func(c *Context, err error) {
+		reported = err
+	})
+
+	var targetCalls int
+	upper := Wrap1RE(registry, "Upper", func(s string) (string, error) {
+		targetCalls++
+		return s + "!", nil
+	})
+
+	// Lenient mode falls back to the original argument
+	result, err := upper("hi")
+	if err != nil || result != "hi!" {
+		t.Fatalf("expected lenient fallback, got %q, %v", result, err)
+	}
+	if reported != nil {
+		t.Errorf("expected no report in lenient mode, got %v", reported)
+	}
+
+	// Strict mode aborts the call
+	registry.SetStrictArgRewrite(true)
+	result, err = upper("hi")
+	if !errors.Is(err, ErrArgType) {
+		t.Fatalf("expected ErrArgType, got %v", err)
+	}
+	if result != "" {
+		t.Errorf("expected zero result, got %q", result)
+	}
+	if !errors.Is(reported, ErrArgType) {
+		t.Errorf("expected OnAdviceError to receive ErrArgType, got %v", reported)
+	}
+	if targetCalls != 1 {
+		t.Errorf("expected target to run only in lenient mode, ran %d times", targetCalls)
+	}
+}
+
+func TestContext_ArgsString(t *testing.T) {
+	c := NewContext("test", 1, "two")
+
+	lazy := LazyArgs(c)
+	if c.argsString != nil {
+		t.Error("expected LazyArgs not to format the arguments")
+	}
+
+	if got := lazy.String(); got != "[1 two]" {
+		t.Errorf("expected '[1 two]', got %q", got)
+	}
+	if c.argsString == nil {
+		t.Error("expected the formatted arguments to be cached")
+	}
+
+	c.SetArg(1, "three")
+	if got := c.ArgsString(); got != "[1 three]" {
+		t.Errorf("expected SetArg to invalidate the cache, got %q", got)
+	}
+	if got := fmt.Sprintf("%v", lazy); got != "[1 three]" {
+		t.Errorf("expected the stringer to render on demand, got %q", got)
+	}
+}
+
+func TestContext_ArgCountAndType(t *testing.T) {
+	var nilPtr *int
+	tests := []struct {
+		name     string
+		args     []any
+		expected []reflect.Type
+	}{
+		{name: "no args", args: nil, expected: nil},
+		{name: "one arg", args: []any{42}, expected: []reflect.Type{reflect.TypeFor[int]()}},
+		{
+			name:     "mixed args",
+			args:     []any{"id", 3.5, []byte("x")},
+			expected: []reflect.Type{reflect.TypeFor[string](), reflect.TypeFor[float64](), reflect.TypeFor[[]byte]()},
+		},
+		{name: "nil arg", args: []any{nil, nilPtr}, expected: []reflect.Type{nil, reflect.TypeFor[*int]()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewContext("test", tt.args...)
+			if c.ArgCount() != len(tt.expected) {
+				t.Errorf("expected %d args, got %d", len(tt.expected), c.ArgCount())
+			}
+			for i, expected := range tt.expected {
+				if got := c.ArgType(i); got != expected {
+					t.Errorf("arg %d: expected type %v, got %v", i, expected, got)
+				}
+			}
+			if c.ArgType(-1) != nil || c.ArgType(len(tt.expected)) != nil {
+				t.Error("expected nil type for out of range index")
+			}
+		})
+	}
+}
+
+func TestContext_Arg(t *testing.T) {
+	c := NewContext("test", "id", 7)
+
+	tests := []struct {
+		name     string
+		index    int
+		expected any
+	}{
+		{name: "first", index: 0, expected: "id"},
+		{name: "last", index: 1, expected: 7},
+		{name: "negative", index: -1, expected: nil},
+		{name: "past end", index: 2, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Arg(tt.index); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
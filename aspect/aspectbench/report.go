@@ -0,0 +1,60 @@
+// Package aspectbench - report runs the predefined scenarios and exports their results as JSON
+package aspectbench
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// ScenarioResult is the benchmark result of a scenario in a report.
+type ScenarioResult struct {
+	Name        string `json:"name"`
+	Iterations  int    `json:"iterations"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Report benchmarks every predefined scenario against a sample target and writes the results to w
+// as a JSON array of ScenarioResult, one entry per scenario in the order of Scenarios, e.g. to
+// track advice overhead across releases as a CI artifact:
+//
+//	go test ./aspect/aspectbench -run TestPerfReport -perf-report=perf.json
+//
+// Each scenario runs for the benchmark time of the test binary (-test.benchtime, 1s by default).
+func Report(w io.Writer) error {
+	results := make([]ScenarioResult, 0, len(Scenarios()))
+	for _, scenario := range Scenarios() {
+		results = append(results, runReportScenario(scenario))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// runReportScenario benchmarks scenario against a formatting target and collects its result.
+func runReportScenario(scenario Scenario) ScenarioResult {
+	format := func(id int) (string, error) {
+		return strconv.Itoa(id), nil
+	}
+	result := testing.Benchmark(func(b *testing.B) {
+		benchmarkScenario(b, scenario, format, func(i int) int { return i % 64 })
+	})
+
+	return ScenarioResult{
+		Name:        scenario.Name,
+		Iterations:  result.N,
+		NsPerOp:     result.NsPerOp(),
+		AllocsPerOp: result.AllocsPerOp(),
+		BytesPerOp:  result.AllocedBytesPerOp(),
+	}
+}
@@ -0,0 +1,56 @@
+// Package aspectbench - report_test validates the JSON benchmark report
+package aspectbench
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// perfReport is the file TestPerfReport writes the report to, if set.
+var perfReport = flag.String("perf-report", "", "write the JSON benchmark report to this file")
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestPerfReport(t *testing.T) {
+	if testing.Short() {
+		t.Skip("benchmarks every scenario")
+	}
+
+	var buf bytes.Buffer
+	if err := Report(&buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("expected a JSON array, got %v", err)
+	}
+	if len(entries) != len(Scenarios()) {
+		t.Fatalf("expected %d entries, got %d", len(Scenarios()), len(entries))
+	}
+	for i, scenario := range Scenarios() {
+		entry := entries[i]
+		if entry["name"] != scenario.Name {
+			t.Errorf("expected entry %d for %s, got %v", i, scenario.Name, entry["name"])
+		}
+		for _, field := range []string{"iterations", "ns_per_op", "allocs_per_op", "bytes_per_op"} {
+			if _, ok := entry[field].(float64); !ok {
+				t.Errorf("%s: expected numeric %s, got %v", scenario.Name, field, entry[field])
+			}
+		}
+		if entry["iterations"] == 0.0 {
+			t.Errorf("%s: expected the scenario to run", scenario.Name)
+		}
+	}
+
+	if *perfReport != "" {
+		if err := os.WriteFile(*perfReport, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("expected the report to be written, got %v", err)
+		}
+	}
+}
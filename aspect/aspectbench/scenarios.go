@@ -0,0 +1,123 @@
+// Package aspectbench - scenarios provides reusable benchmark scenarios for measuring advice overhead.
+//
+// Each scenario installs a baseline advice setup around a user's target function, so custom
+// advice can be benchmarked against the same shapes the aspect package benchmarks itself with:
+//
+//	func BenchmarkGetUser(b *testing.B) {
+//		for _, scenario := range aspectbench.Scenarios() {
+//			aspectbench.RunScenario(b, scenario, getUser, func(i int) int { return i % 100 })
+//		}
+//	}
+package aspectbench
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// Scenario is a named advice setup installed on the benchmarked function.
+type Scenario struct {
+	Name    string
+	Install func(registry *aspect.Registry, funcKey aspect.FuncKey) // Install adds the scenario's advice; nil installs none.
+}
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// funcKey is the key the benchmarked function is registered under.
+const funcKey aspect.FuncKey = "aspectbench.target"
+
+var (
+	// NoAdvice wraps the target without any advice, measuring the bare wrapping cost.
+	NoAdvice = Scenario{Name: "NoAdvice"}
+
+	// BeforeOnly installs a single no-op Before advice.
+	BeforeOnly = Scenario{
+		Name: "BeforeOnly",
+		Install: func(registry *aspect.Registry, funcKey aspect.FuncKey) {
+			registry.MustAddAdvice(funcKey, aspect.Advice{Type: aspect.Before, Handler: func(c *aspect.Context) error {
+				return nil
+			}})
+		},
+	}
+
+	// AroundCache installs Around advice serving results from a cache keyed by the argument,
+	// filled by AfterReturning advice. Inputs repeating across iterations measure the hit path.
+	AroundCache = Scenario{
+		Name: "AroundCache",
+		Install: func(registry *aspect.Registry, funcKey aspect.FuncKey) {
+			var cache sync.Map
+			registry.MustAddAdvice(funcKey, aspect.Advice{Type: aspect.Around, Handler: func(c *aspect.Context) error {
+				if result, hit := cache.Load(fmt.Sprint(c.Args[0])); hit {
+					c.SetResult(0, result)
+					c.Skipped = true
+				}
+				return nil
+			}})
+			registry.MustAddAdvice(funcKey, aspect.Advice{Type: aspect.AfterReturning, Handler: func(c *aspect.Context) error {
+				cache.Store(fmt.Sprint(c.Args[0]), c.GetResult(0))
+				return nil
+			}})
+		},
+	}
+
+	// MetadataHeavy installs tracing-like advice writing and reading several metadata entries.
+	MetadataHeavy = Scenario{
+		Name: "MetadataHeavy",
+		Install: func(registry *aspect.Registry, funcKey aspect.FuncKey) {
+			keys := []string{"traceId", "spanId", "parentId", "service", "endpoint", "correlationId"}
+			registry.MustAddAdvice(funcKey, aspect.Advice{Type: aspect.Before, Handler: func(c *aspect.Context) error {
+				for _, key := range keys {
+					c.SetMetadataVal(key, key+"-value")
+				}
+				c.SetMetadataVal("startTime", time.Now())
+				return nil
+			}})
+			registry.MustAddAdvice(funcKey, aspect.Advice{Type: aspect.After, Handler: func(c *aspect.Context) error {
+				for _, key := range keys {
+					_, _ = c.GetMetadataVal(key)
+				}
+				_, _ = c.GetMetadataVal("startTime")
+				return nil
+			}})
+		},
+	}
+)
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Scenarios returns the predefined scenarios, from the cheapest to the most expensive.
+func Scenarios() []Scenario {
+	return []Scenario{NoAdvice, BeforeOnly, AroundCache, MetadataHeavy}
+}
+
+// RunScenario benchmarks target wrapped with the advice of scenario as a sub-benchmark named after it.
+// Each iteration calls the target with input(i). Every run uses a fresh registry.
+func RunScenario[A, R any](b *testing.B, scenario Scenario, target func(A) (R, error), input func(i int) A) {
+	b.Run(scenario.Name, func(b *testing.B) {
+		benchmarkScenario(b, scenario, target, input)
+	})
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// benchmarkScenario runs the benchmark loop of a scenario.
+func benchmarkScenario[A, R any](b *testing.B, scenario Scenario, target func(A) (R, error), input func(i int) A) {
+	registry := aspect.NewRegistry()
+	registry.MustRegister(funcKey)
+	if scenario.Install != nil {
+		scenario.Install(registry, funcKey)
+	}
+	wrapped := aspect.Wrap1RE(registry, funcKey, target)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = wrapped(input(i))
+	}
+}
@@ -0,0 +1,37 @@
+// Package aspectbench - scenarios_test benchmarks the predefined scenarios against a sample target
+package aspectbench
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func BenchmarkScenarios(b *testing.B) {
+	format := func(id int) (string, error) {
+		return strconv.Itoa(id), nil
+	}
+
+	for _, scenario := range Scenarios() {
+		RunScenario(b, scenario, format, func(i int) int { return i % 64 })
+	}
+}
+
+func TestScenarios_Install(t *testing.T) {
+	expected := map[string]int{"NoAdvice": 0, "BeforeOnly": 1, "AroundCache": 2, "MetadataHeavy": 2}
+
+	for _, scenario := range Scenarios() {
+		registry := aspect.NewRegistry()
+		registry.MustRegister(funcKey)
+		if scenario.Install != nil {
+			scenario.Install(registry, funcKey)
+		}
+
+		if got := registry.GetAdviceCount(funcKey); got != expected[scenario.Name] {
+			t.Errorf("%s: expected %d advice, got %d", scenario.Name, expected[scenario.Name], got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package aspectflight - singleflight coalesces concurrent invocations with the same key.
+//
+// It lives in its own package so the core aspect package stays free of external dependencies.
+package aspectflight
+
+import (
+	"golang.org/x/sync/singleflight"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// outcome is the result of the leading invocation shared with the waiting ones.
+type outcome struct {
+	results []any
+	err     error
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SingleFlight returns Around advice coalescing in-flight invocations: while an invocation with a
+// key is in progress, further invocations with the same key wait for it, skip the target and
+// receive its results and error. The key is computed from the invocation by keyFunc
+// (aspect.DefaultKey if nil).
+//
+// Unlike aspect.Idempotent nothing is kept once the leading invocation completes, so the next call
+// runs the target again. An invocation whose context is cancelled gives up with the context
+// error; when it was leading the flight, its waiters receive that error too.
+// The advice should have a high priority so that it runs before other Around advice.
+func SingleFlight(keyFunc func(*aspect.Context) string) aspect.AdviceFunc {
+	if keyFunc == nil {
+		keyFunc = aspect.DefaultKey
+	}
+
+	var group singleflight.Group
+
+	return func(c *aspect.Context) error {
+		elected := make(chan struct{})
+		done := make(chan outcome, 1)
+
+		// The function only runs for the leader and holds the flight open until its invocation completes
+		results := group.DoChan(keyFunc(c), func() (any, error) {
+			close(elected)
+			return <-done, nil
+		})
+
+		select {
+		case <-elected:
+			c.OnComplete(func(c *aspect.Context) {
+				done <- outcome{results: append([]any(nil), c.Results...), err: c.Error}
+			})
+			return nil
+		case result := <-results:
+			shared := result.Val.(outcome)
+			c.Skipped = true
+			c.Results = append([]any(nil), shared.results...)
+			c.Error = shared.err
+			return nil
+		case <-c.Context().Done():
+			// Should this invocation lead the flight, land it so the waiters are not stuck
+			done <- outcome{err: c.Context().Err()}
+			return c.Context().Err()
+		}
+	}
+}
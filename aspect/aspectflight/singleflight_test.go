@@ -0,0 +1,70 @@
+// Package aspectflight - singleflight_test validates coalescing of concurrent invocations
+package aspectflight
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestSingleFlight(t *testing.T) {
+	const callers = 50
+
+	registry := aspect.NewRegistry()
+	registry.MustRegister("LoadConfig")
+
+	var arrived atomic.Int32
+	registry.MustAddAdvice("LoadConfig", aspect.Advice{Type: aspect.Before, Handler: func(c *aspect.Context) error {
+		arrived.Add(1)
+		return nil
+	}})
+	registry.MustAddAdvice("LoadConfig", aspect.Advice{
+		Type:     aspect.Around,
+		Priority: 100,
+		Handler: SingleFlight(func(c *aspect.Context) string {
+			return c.Args[0].(string)
+		}),
+	})
+
+	var runs atomic.Int32
+	loadConfig := aspect.Wrap1RE(registry, "LoadConfig", func(name string) (string, error) {
+		runs.Add(1)
+		for arrived.Load() < callers {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond) // Let the last callers join the flight
+		return "config:" + name, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = loadConfig("app")
+		}(i)
+	}
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Errorf("expected target to run once, ran %d times", runs.Load())
+	}
+	for i, result := range results {
+		if result != "config:app" {
+			t.Errorf("caller %d: expected 'config:app', got %q", i, result)
+		}
+	}
+
+	// Nothing is kept after the flight lands
+	arrived.Store(callers)
+	_, _ = loadConfig("app")
+	if runs.Load() != 2 {
+		t.Errorf("expected a later call to run the target again, runs=%d", runs.Load())
+	}
+}
@@ -0,0 +1,151 @@
+// Package aspecttag - tags wires advice from struct tags for config-driven aspect setup.
+package aspecttag
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// taggedAdvice is advice parsed from the tag of a struct field, awaiting installation.
+type taggedAdvice struct {
+	field   string
+	funcKey aspect.FuncKey
+	advice  aspect.Advice
+}
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// TagName is the struct tag key read by RegisterFromTags.
+const TagName = "aspect"
+
+// adviceTypes maps tag values to advice types.
+var adviceTypes = map[string]aspect.AdviceType{
+	"before":         aspect.Before,
+	"after":          aspect.After,
+	"around":         aspect.Around,
+	"afterreturning": aspect.AfterReturning,
+	"afterthrowing":  aspect.AfterThrowing,
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// RegisterFromTags installs advice described by the struct tags of cfg.
+//
+// Each tagged field describes one advice:
+//
+//	type AOPConfig struct {
+//		LogGetUser bool `aspect:"func=GetUser,type=before,priority=100,handler=log"`
+//		Audit      bool `aspect:"func=DeleteUser,type=after,group=audit"`
+//	}
+//
+// Supported keys are func (required), type (required: before, after, around,
+// afterReturning, afterThrowing), priority, group and handler. The handler is
+// looked up in handlers by name, defaulting to the field name. A bool field acts
+// as a switch: the advice is only installed when the field is true. Functions are
+// registered if needed. cfg must be a struct or a pointer to a struct.
+//
+// Installation is atomic: all tags are validated before any advice is installed, and advice
+// installed for earlier fields is removed again if a later one fails to install. Functions
+// registered on the way stay registered.
+func RegisterFromTags(registry *aspect.Registry, cfg any, handlers map[string]aspect.AdviceFunc) error {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a struct, got %T", cfg)
+	}
+
+	var pending []taggedAdvice
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag, ok := field.Tag.Lookup(TagName)
+		if !ok {
+			continue
+		}
+		if value.Field(i).Kind() == reflect.Bool && !value.Field(i).Bool() {
+			continue
+		}
+
+		funcKey, advice, handlerName, err := parseTag(tag, field.Name)
+		if err != nil {
+			return fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+
+		handler, exists := handlers[handlerName]
+		if !exists {
+			return fmt.Errorf("field '%s': handler '%s' not found", field.Name, handlerName)
+		}
+		advice.Handler = handler
+		pending = append(pending, taggedAdvice{field: field.Name, funcKey: funcKey, advice: advice})
+	}
+
+	installed := make([]aspect.AdviceID, 0, len(pending))
+	for _, tagged := range pending {
+		registry.RegisterOrGet(tagged.funcKey)
+		id, err := registry.AddRemovableAdvice(tagged.funcKey, tagged.advice)
+		if err != nil {
+			for j, id := range installed {
+				_, _ = registry.RemoveAdvice(pending[j].funcKey, id)
+			}
+			return fmt.Errorf("field '%s': %w", tagged.field, err)
+		}
+		installed = append(installed, id)
+	}
+	return nil
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// parseTag parses an aspect struct tag into the advised function, the advice and its handler name.
+func parseTag(tag, fieldName string) (aspect.FuncKey, aspect.Advice, string, error) {
+	var funcKey aspect.FuncKey
+	var advice aspect.Advice
+	handlerName := fieldName
+	hasType := false
+
+	for _, pair := range strings.Split(tag, ",") {
+		key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return "", advice, "", fmt.Errorf("malformed tag entry '%s'", pair)
+		}
+
+		switch key {
+		case "func":
+			funcKey = aspect.FuncKey(val)
+		case "type":
+			adviceType, exists := adviceTypes[strings.ToLower(val)]
+			if !exists {
+				return "", advice, "", fmt.Errorf("unknown advice type '%s'", val)
+			}
+			advice.Type = adviceType
+			hasType = true
+		case "priority":
+			priority, err := strconv.Atoi(val)
+			if err != nil {
+				return "", advice, "", fmt.Errorf("invalid priority '%s': %w", val, err)
+			}
+			advice.Priority = priority
+		case "group":
+			advice.Group = val
+		case "handler":
+			handlerName = val
+		default:
+			return "", advice, "", fmt.Errorf("unknown tag key '%s'", key)
+		}
+	}
+
+	if funcKey == "" {
+		return "", advice, "", fmt.Errorf("missing 'func' in tag")
+	}
+	if !hasType {
+		return "", advice, "", fmt.Errorf("missing 'type' in tag")
+	}
+	return funcKey, advice, handlerName, nil
+}
@@ -0,0 +1,144 @@
+// Package aspecttag - tags_test validates advice wiring from struct tags
+package aspecttag
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegisterFromTags(t *testing.T) {
+	type config struct {
+		LogLevel   string `aspect:"func=GetUser,type=before,priority=100,handler=log"`
+		Audit      bool   `aspect:"func=GetUser,type=after,group=audit"`
+		Debug      bool   `aspect:"func=GetUser,type=before,priority=200,handler=log"`
+		Validation string `aspect:"func=GetUser,type=before,priority=150"`
+		Untagged   string
+	}
+
+	var order []string
+	handlers := map[string]aspect.AdviceFunc{
+		"log": func(c *aspect.Context) error {
+			order = append(order, "log")
+			return nil
+		},
+		"Audit": func(c *aspect.Context) error {
+			order = append(order, "audit")
+			return nil
+		},
+		"Validation": func(c *aspect.Context) error {
+			order = append(order, "validation")
+			return nil
+		},
+	}
+
+	registry := aspect.NewRegistry()
+	cfg := &config{LogLevel: "info", Audit: true, Debug: false}
+	if err := RegisterFromTags(registry, cfg, handlers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !registry.IsRegistered("GetUser") {
+		t.Fatal("expected GetUser to be registered")
+	}
+	if count := registry.GetAdviceCount("GetUser"); count != 3 {
+		t.Fatalf("expected 3 advice (disabled Debug skipped), got %d", count)
+	}
+
+	aspect.Wrap0(registry, "GetUser", func() {})()
+
+	expected := []string{"validation", "log", "audit"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+
+	registry.SetGroupEnabled("audit", false)
+	order = nil
+	aspect.Wrap0(registry, "GetUser", func() {})()
+	if !reflect.DeepEqual(order, []string{"validation", "log"}) {
+		t.Errorf("expected audit group to be disabled, got %v", order)
+	}
+}
+
+func TestRegisterFromTags_Errors(t *testing.T) {
+	handlers := map[string]aspect.AdviceFunc{
+		"h": func(c *aspect.Context) error { return nil },
+	}
+
+	tests := []struct {
+		name string
+		cfg  any
+	}{
+		{name: "not a struct", cfg: 42},
+		{name: "missing func", cfg: struct {
+			F string `aspect:"type=before,handler=h"`
+		}{}},
+		{name: "missing type", cfg: struct {
+			F string `aspect:"func=X,handler=h"`
+		}{}},
+		{name: "unknown type", cfg: struct {
+			F string `aspect:"func=X,type=sideways,handler=h"`
+		}{}},
+		{name: "invalid priority", cfg: struct {
+			F string `aspect:"func=X,type=before,priority=high,handler=h"`
+		}{}},
+		{name: "unknown key", cfg: struct {
+			F string `aspect:"func=X,type=before,color=red,handler=h"`
+		}{}},
+		{name: "malformed entry", cfg: struct {
+			F string `aspect:"func=X,before"`
+		}{}},
+		{name: "missing handler", cfg: struct {
+			F string `aspect:"func=X,type=before"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterFromTags(aspect.NewRegistry(), tt.cfg, handlers); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRegisterFromTags_Atomic(t *testing.T) {
+	handlers := map[string]aspect.AdviceFunc{
+		"h": func(c *aspect.Context) error { return nil },
+	}
+
+	// A malformed later tag installs nothing
+	registry := aspect.NewRegistry()
+	invalid := struct {
+		First  string `aspect:"func=GetUser,type=before,handler=h"`
+		Second string `aspect:"func=SaveUser,type=sideways,handler=h"`
+	}{}
+	if err := RegisterFromTags(registry, invalid, handlers); err == nil {
+		t.Fatal("expected error")
+	}
+	if registry.IsRegistered("GetUser") {
+		t.Error("expected no function to be registered when a tag is invalid")
+	}
+
+	// A later field failing to install removes the advice of earlier fields
+	registry = aspect.NewRegistry()
+	registry.MustRegister("SaveUser")
+	registry.FreezeAll()
+	frozen := struct {
+		First  string `aspect:"func=GetUser,type=before,handler=h"`
+		Second string `aspect:"func=SaveUser,type=before,handler=h"`
+	}{}
+	if err := RegisterFromTags(registry, frozen, handlers); err == nil {
+		t.Fatal("expected error")
+	}
+	chain, err := registry.GetAdviceChain("GetUser")
+	if err != nil {
+		t.Fatalf("expected GetUser to be registered, got %v", err)
+	}
+	if chain.Count() != 0 {
+		t.Errorf("expected the advice of earlier fields to be removed, got %d advice", chain.Count())
+	}
+}
@@ -0,0 +1,85 @@
+// Package aspecttest - hammer provides a load harness for driving wrapped functions under concurrency.
+//
+// The aspecttest package collects helpers for testing code built on the aspect package.
+package aspecttest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// HammerResult summarizes a Hammer run.
+type HammerResult struct {
+	TotalCalls int           // TotalCalls is the number of completed calls.
+	Errors     int           // Errors is the number of calls that returned an error.
+	Duration   time.Duration // Duration is the wall time of the whole run.
+	P50        time.Duration // P50 is the median call latency.
+	P99        time.Duration // P99 is the 99th percentile call latency.
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Hammer calls wrapped callsPer times from each of goroutines goroutines concurrently and
+// reports the latency distribution, e.g. to validate advice under contention:
+//
+//	result := aspecttest.Hammer(func() error {
+//		_, err := GetUser(42)
+//		return err
+//	}, 16, 1000)
+//
+// Wrap functions without an error result in a closure returning nil.
+func Hammer(wrapped func() error, goroutines, callsPer int) HammerResult {
+	goroutines, callsPer = max(goroutines, 0), max(callsPer, 0)
+	latencies := make([][]time.Duration, goroutines)
+	errs := make([]int, goroutines)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			local := make([]time.Duration, 0, callsPer)
+			<-start
+			for i := 0; i < callsPer; i++ {
+				callStart := time.Now()
+				if err := wrapped(); err != nil {
+					errs[g]++
+				}
+				local = append(local, time.Since(callStart))
+			}
+			latencies[g] = local
+		}(g)
+	}
+
+	began := time.Now()
+	close(start)
+	wg.Wait()
+
+	result := HammerResult{Duration: time.Since(began)}
+	all := make([]time.Duration, 0, goroutines*callsPer)
+	for g := range latencies {
+		all = append(all, latencies[g]...)
+		result.Errors += errs[g]
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	result.TotalCalls = len(all)
+	result.P50 = percentile(all, 50)
+	result.P99 = percentile(all, 99)
+	return result
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,68 @@
+// Package aspecttest - hammer_test validates the concurrent load harness
+package aspecttest
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestHammer(t *testing.T) {
+	registry := aspect.NewRegistry()
+	registry.MustRegister("Work")
+
+	var beforeCalls atomic.Int64
+	registry.MustAddAdvice("Work", aspect.Advice{Type: aspect.Before, Handler: func(c *aspect.Context) error {
+		beforeCalls.Add(1)
+		return nil
+	}})
+
+	var calls atomic.Int64
+	work := aspect.Wrap0E(registry, "Work", func() error {
+		if calls.Add(1)%10 == 0 {
+			return errors.New("every tenth call fails")
+		}
+		return nil
+	})
+
+	const goroutines, callsPer = 8, 50
+	result := Hammer(work, goroutines, callsPer)
+
+	if result.TotalCalls != goroutines*callsPer {
+		t.Errorf("expected %d calls, got %d", goroutines*callsPer, result.TotalCalls)
+	}
+	if beforeCalls.Load() != goroutines*callsPer {
+		t.Errorf("expected advice to run for every call, ran %d times", beforeCalls.Load())
+	}
+	if result.Errors != goroutines*callsPer/10 {
+		t.Errorf("expected %d errors, got %d", goroutines*callsPer/10, result.Errors)
+	}
+	if result.P50 <= 0 || result.P99 < result.P50 || result.Duration <= 0 {
+		t.Errorf("unexpected latency summary: %+v", result)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i + 1)
+	}
+
+	if got := percentile(sorted, 50); got != 50 {
+		t.Errorf("expected P50 of 50, got %d", got)
+	}
+	if got := percentile(sorted, 99); got != 99 {
+		t.Errorf("expected P99 of 99, got %d", got)
+	}
+	if got := percentile(sorted[:1], 99); got != 1 {
+		t.Errorf("expected the only value, got %d", got)
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("expected 0 for no latencies, got %d", got)
+	}
+}
@@ -0,0 +1,90 @@
+// Package aspecttest - order provides assertions on the execution order of advice
+package aspecttest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// missingStep is shown in a diff column for a step beyond the end of its list.
+const missingStep = "<missing>"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// DiffOrder returns a readable side-by-side diff of an expected and an actual execution order,
+// marking mismatched steps with "!", or "" if they are equal:
+//
+//	  # expected  actual
+//	  0 auth      auth
+//	! 1 log       cache
+//	! 2 cache     <missing>
+func DiffOrder(expected, actual []string) string {
+	if slices.Equal(expected, actual) {
+		return ""
+	}
+
+	width := len("expected")
+	for _, step := range expected {
+		width = max(width, len(step))
+	}
+	if len(actual) < len(expected) {
+		width = max(width, len(missingStep))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  # %-*s  %s\n", width, "expected", "actual")
+	for i := 0; i < max(len(expected), len(actual)); i++ {
+		want, got := stepAt(expected, i), stepAt(actual, i)
+		marker := " "
+		if i >= len(expected) || i >= len(actual) || want != got {
+			marker = "!"
+		}
+		fmt.Fprintf(&b, "%s %d %-*s  %s\n", marker, i, width, want, got)
+	}
+	return b.String()
+}
+
+// AssertOrder runs the advice chain of funcKey with a no-op target and checks that the advice
+// runs in the expected order, reporting a DiffOrder diff on mismatch. Steps are advice names
+// (see aspect.Advice.Name; unnamed advice is reported by its handler's function name) and
+// aspect.PhaseTarget for the target:
+//
+//	aspecttest.AssertOrder(t, registry, "GetUser", "auth", "cache", aspect.PhaseTarget, "audit")
+//
+// The chain runs without arguments, so advice reading them sees nil.
+func AssertOrder(t testing.TB, registry *aspect.Registry, funcKey aspect.FuncKey, expected ...string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var actual []string
+	record := func(step string) {
+		mu.Lock()
+		defer mu.Unlock()
+		actual = append(actual, step)
+	}
+
+	ctx := aspect.WithAdviceTrace(context.Background(), func(step aspect.PlanStep) { record(step.Name) })
+	registry.Execute(ctx, funcKey, func(c *aspect.Context) { record(aspect.PhaseTarget) })
+
+	if diff := DiffOrder(expected, actual); diff != "" {
+		t.Errorf("unexpected execution order of %s:\n%s", funcKey, diff)
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// stepAt returns the step at index i, or missingStep beyond the end of steps.
+func stepAt(steps []string, i int) string {
+	if i < len(steps) {
+		return steps[i]
+	}
+	return missingStep
+}
@@ -0,0 +1,75 @@
+// Package aspecttest - order_test validates the execution order assertions
+package aspecttest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// recordingTB is a testing.TB recording failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// newOrderRegistry returns a registry whose "Save" chain runs auth, log, the target, then audit.
+func newOrderRegistry() *aspect.Registry {
+	registry := aspect.NewRegistry()
+	registry.MustRegister("Save")
+	noop := func(c *aspect.Context) error { return nil }
+	registry.MustAddAdvice("Save", aspect.Advice{Type: aspect.Before, Name: "log", Priority: 10, Handler: noop})
+	registry.MustAddAdvice("Save", aspect.Advice{Type: aspect.Before, Name: "auth", Priority: 20, Handler: noop})
+	registry.MustAddAdvice("Save", aspect.Advice{Type: aspect.After, Name: "audit", Handler: noop})
+	return registry
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestDiffOrder(t *testing.T) {
+	if diff := DiffOrder([]string{"a", "b"}, []string{"a", "b"}); diff != "" {
+		t.Errorf("expected no diff for equal orders, got:\n%s", diff)
+	}
+
+	diff := DiffOrder([]string{"auth", "log", "cache"}, []string{"auth", "cache"})
+	expected := "" +
+		"  # expected   actual\n" +
+		"  0 auth       auth\n" +
+		"! 1 log        cache\n" +
+		"! 2 cache      <missing>\n"
+	if diff != expected {
+		t.Errorf("expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+}
+
+func TestAssertOrder(t *testing.T) {
+	registry := newOrderRegistry()
+	AssertOrder(t, registry, "Save", "auth", "log", aspect.PhaseTarget, "audit")
+}
+
+func TestAssertOrder_Mismatch(t *testing.T) {
+	registry := newOrderRegistry()
+
+	recorder := &recordingTB{TB: t}
+	AssertOrder(recorder, registry, "Save", "log", "auth", aspect.PhaseTarget, "audit")
+
+	if len(recorder.failures) != 1 {
+		t.Fatalf("expected one failure, got %d", len(recorder.failures))
+	}
+	failure := recorder.failures[0]
+	for _, line := range []string{"! 0 log       auth", "! 1 auth      log", "  2 Target    Target"} {
+		if !strings.Contains(failure, line) {
+			t.Errorf("expected the failure to contain %q, got:\n%s", line, failure)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package aspecttest - spy provides advice recording its invocations for assertions
+package aspecttest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// Spy is advice recording the invocations it runs for, to assert whether advice ran:
+//
+//	spy := aspecttest.NewSpy(nil)
+//	registry.MustAddAdvice("GetUser", aspect.Advice{Type: aspect.Around, Name: "cache", Handler: spy.Handle})
+//	GetUser(aspect.Suppress(ctx, "cache"), 42)
+//	spy.AssertNotCalled(t)
+//
+// It is safe for concurrent use.
+type Spy struct {
+	handler aspect.AdviceFunc
+	calls   []*aspect.Context
+	mu      sync.Mutex
+}
+
+// NewSpy creates a spy delegating to handler once it recorded an invocation (nil does nothing).
+func NewSpy(handler aspect.AdviceFunc) *Spy {
+	return &Spy{handler: handler}
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Handle is the advice handler of the spy.
+func (spy *Spy) Handle(c *aspect.Context) error {
+	spy.mu.Lock()
+	spy.calls = append(spy.calls, c)
+	spy.mu.Unlock()
+
+	if spy.handler == nil {
+		return nil
+	}
+	return spy.handler(c)
+}
+
+// Calls returns the number of invocations the spy ran for.
+func (spy *Spy) Calls() int {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	return len(spy.calls)
+}
+
+// Contexts returns the execution contexts of the invocations the spy ran for, in order.
+func (spy *Spy) Contexts() []*aspect.Context {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	return append([]*aspect.Context(nil), spy.calls...)
+}
+
+// Reset forgets the recorded invocations.
+func (spy *Spy) Reset() {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	spy.calls = nil
+}
+
+// AssertCalled checks that the spy ran at least once.
+func (spy *Spy) AssertCalled(t testing.TB) {
+	t.Helper()
+	if spy.Calls() == 0 {
+		t.Errorf("expected advice to run, it did not")
+	}
+}
+
+// AssertCalledTimes checks that the spy ran exactly n times.
+func (spy *Spy) AssertCalledTimes(t testing.TB, n int) {
+	t.Helper()
+	if calls := spy.Calls(); calls != n {
+		t.Errorf("expected advice to run %d times, ran %d times", n, calls)
+	}
+}
+
+// AssertNotCalled checks that the spy never ran, e.g. that advice was skipped by its condition,
+// a disabled group or suppression.
+func (spy *Spy) AssertNotCalled(t testing.TB) {
+	t.Helper()
+	if calls := spy.Calls(); calls != 0 {
+		t.Errorf("expected advice not to run, ran %d times", calls)
+	}
+}
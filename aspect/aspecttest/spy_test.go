@@ -0,0 +1,72 @@
+// Package aspecttest - spy_test validates the advice spy and its assertions
+package aspecttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestSpy_AssertNotCalled_ConditionFalse(t *testing.T) {
+	registry := aspect.NewRegistry()
+	registry.MustRegister("GetUser")
+
+	spy := NewSpy(nil)
+	registry.MustAddAdvice("GetUser", aspect.Advice{
+		Type:      aspect.Around,
+		Handler:   spy.Handle,
+		Condition: func(c *aspect.Context) bool { return false },
+	})
+
+	getUser := aspect.Wrap1RE(registry, "GetUser", func(id int) (string, error) { return "alice", nil })
+	_, _ = getUser(42)
+
+	spy.AssertNotCalled(t)
+}
+
+func TestSpy_AssertNotCalled_Suppressed(t *testing.T) {
+	registry := aspect.NewRegistry()
+	registry.MustRegister("GetUser")
+
+	spy := NewSpy(nil)
+	registry.MustAddAdvice("GetUser", aspect.Advice{Type: aspect.Around, Name: "cache", Handler: spy.Handle})
+
+	registry.Execute(aspect.Suppress(context.Background(), "cache"), "GetUser", func(c *aspect.Context) {})
+	spy.AssertNotCalled(t)
+
+	registry.Execute(context.Background(), "GetUser", func(c *aspect.Context) {})
+	spy.AssertCalled(t)
+	spy.AssertCalledTimes(t, 1)
+}
+
+func TestSpy_AssertionsFail(t *testing.T) {
+	spy := NewSpy(nil)
+	recorder := &recordingTB{TB: t}
+
+	spy.AssertCalled(recorder)
+	_ = spy.Handle(aspect.NewContext("Fn"))
+	spy.AssertNotCalled(recorder)
+	spy.AssertCalledTimes(recorder, 2)
+
+	expected := []string{
+		"expected advice to run, it did not",
+		"expected advice not to run, ran 1 times",
+		"expected advice to run 2 times, ran 1 times",
+	}
+	if len(recorder.failures) != len(expected) {
+		t.Fatalf("expected %d failures, got %v", len(expected), recorder.failures)
+	}
+	for i, failure := range recorder.failures {
+		if failure != expected[i] {
+			t.Errorf("failure %d: expected %q, got %q", i, expected[i], failure)
+		}
+	}
+
+	spy.Reset()
+	if spy.Calls() != 0 || len(spy.Contexts()) != 0 {
+		t.Error("expected Reset to forget the invocations")
+	}
+}
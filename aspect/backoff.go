@@ -0,0 +1,38 @@
+// Package aspect - backoff provides backoff strategies for retrying advice
+package aspect
+
+import "time"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// ExponentialBackoff returns a backoff function doubling the delay on every attempt.
+// Attempt 0 waits base, attempt n waits base*2^n, never more than maxDelay.
+//
+// jitter in [0, 1] controls how much of the delay is randomized: 0 disables jitter,
+// 1 applies full jitter (a delay anywhere in [0, d)), values in between apply partial
+// jitter (a delay in [d*(1-jitter), d]). Randomness comes from the source set with SetSampleRNG.
+func ExponentialBackoff(base, maxDelay time.Duration, jitter float64) func(attempt int) time.Duration {
+	return ExponentialBackoffWithRand(base, maxDelay, jitter, randomFloat64)
+}
+
+// ExponentialBackoffWithRand is like ExponentialBackoff but draws randomness from random,
+// which must return values in [0, 1). It allows deterministic backoff in tests.
+func ExponentialBackoffWithRand(base, maxDelay time.Duration, jitter float64, random func() float64) func(attempt int) time.Duration {
+	jitter = max(0, min(1, jitter))
+
+	return func(attempt int) time.Duration {
+		delay := min(base, maxDelay)
+		for i := 0; i < attempt && delay < maxDelay; i++ {
+			if delay > maxDelay/2 {
+				delay = maxDelay
+				break
+			}
+			delay *= 2
+		}
+
+		if jitter == 0 {
+			return delay
+		}
+		return delay - time.Duration(float64(delay)*jitter*random())
+	}
+}
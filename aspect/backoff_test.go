@@ -0,0 +1,72 @@
+// Package aspect - backoff_test validates backoff strategies
+package aspect
+
+import (
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestExponentialBackoff_GrowsMonotonically(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, time.Second, 0)
+
+	expected := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		80 * time.Millisecond,
+	}
+	for attempt, want := range expected {
+		if got := backoff(attempt); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, want, got)
+		}
+	}
+}
+
+func TestExponentialBackoff_Cap(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond, 0)
+
+	for _, attempt := range []int{4, 10, 100} {
+		if got := backoff(attempt); got != 100*time.Millisecond {
+			t.Errorf("attempt %d: expected cap 100ms, got %v", attempt, got)
+		}
+	}
+}
+
+func TestExponentialBackoff_JitterBounds(t *testing.T) {
+	const attempt = 3 // 80ms before jitter
+	base := 10 * time.Millisecond
+
+	tests := []struct {
+		name   string
+		jitter float64
+		random float64
+		want   time.Duration
+	}{
+		{name: "full jitter", jitter: 1, random: 0.75, want: 20 * time.Millisecond},
+		{name: "full jitter, zero draw", jitter: 1, random: 0, want: 80 * time.Millisecond},
+		{name: "full jitter, half draw", jitter: 1, random: 0.5, want: 40 * time.Millisecond},
+		{name: "partial jitter", jitter: 0.25, random: 0.75, want: 65 * time.Millisecond},
+		{name: "jitter clamped above 1", jitter: 5, random: 0.5, want: 40 * time.Millisecond},
+		{name: "jitter clamped below 0", jitter: -1, random: 0.5, want: 80 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backoff := ExponentialBackoffWithRand(base, time.Second, tt.jitter, func() float64 { return tt.random })
+			if got := backoff(attempt); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// With the real RNG every delay stays within the partial jitter window.
+	backoff := ExponentialBackoff(base, time.Second, 0.5)
+	for i := 0; i < 100; i++ {
+		got := backoff(attempt)
+		if got < 40*time.Millisecond || got > 80*time.Millisecond {
+			t.Fatalf("delay %v outside jitter bounds [40ms, 80ms]", got)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package aspect - batch provides batch invocations with batch-level and item-level advice
+package aspect
+
+import (
+	"context"
+	"errors"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// BatchInvoker runs batches of items through two advice chains: the advice of the batch's
+// function key runs once per batch, the advice of its item key (see BatchItemKey) once per item.
+type BatchInvoker struct {
+	batch *wrapSite
+	item  *wrapSite
+}
+
+// batchKey is the context key of the batch-level execution context.
+type batchKey struct{}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Batch returns an invoker giving batch processors "begin batch / per item / end batch" semantics:
+//
+//	registry.MustAddAdvice("Import", aspect.Advice{Type: aspect.Before, Handler: openTransaction})
+//	registry.MustAddAdvice(aspect.BatchItemKey("Import"), aspect.Advice{Type: aspect.Before, Handler: validateRow})
+//	err := registry.Batch("Import").Run(rows, importRow)
+//
+// Neither key has to be registered; a key without advice simply runs without it.
+func (registry *Registry) Batch(funcKey FuncKey) *BatchInvoker {
+	return &BatchInvoker{
+		batch: newWrapSite(registry, funcKey),
+		item:  newWrapSite(registry, BatchItemKey(funcKey)),
+	}
+}
+
+// BatchItemKey returns the function key of the item-level advice of a batch.
+func BatchItemKey(funcKey FuncKey) FuncKey {
+	return funcKey + ".Item"
+}
+
+// Run runs a batch: the batch-level advice runs once around the whole batch, with the items as
+// arguments, and perItem runs once per item through the item-level advice, with the item as
+// its single argument. perItem reports failures through the item context's Error, which does
+// not stop the batch. Item contexts share the batch-scoped metadata through BatchContext.
+// Run returns the batch's error, which joins the errors of all failed items.
+func (invoker *BatchInvoker) Run(items []any, perItem func(*Context)) error {
+	return invoker.RunContext(context.Background(), items, perItem)
+}
+
+// RunContext runs a batch like Run using a specific context.Context.
+func (invoker *BatchInvoker) RunContext(ctx context.Context, items []any, perItem func(*Context)) error {
+	c := executeWithAdviceContext(invoker.batch, ctx, func(batchC *Context) {
+		itemCtx := context.WithValue(batchC.Context(), batchKey{}, batchC)
+
+		var errs []error
+		for _, item := range items {
+			itemC := executeInvocation(invoker.item, itemCtx, perItem, item)
+			if itemC.Error != nil {
+				errs = append(errs, itemC.Error)
+			}
+		}
+		batchC.Error = errors.Join(errs...)
+	}, items...)
+	return c.Error
+}
+
+// BatchContext returns the batch-level execution context of an item context of a batch (see
+// Registry.Batch), or nil outside of batches. Item advice uses it to share batch-scoped
+// metadata, e.g. a transaction opened by batch-level Before advice.
+func (c *Context) BatchContext() *Context {
+	batchC, _ := c.Context().Value(batchKey{}).(*Context)
+	return batchC
+}
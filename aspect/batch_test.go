@@ -0,0 +1,77 @@
+// Package aspect - batch_test validates batch invocations with batch-level and item-level advice
+package aspect
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_Batch(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Import")
+	registry.MustRegister(BatchItemKey("Import"))
+
+	var batchBefore, batchAfter, itemBefore int
+	registry.MustAddAdvice("Import", Advice{Type: Before, Handler: func(c *Context) error {
+		batchBefore++
+		c.SetMetadataVal("tx", "tx-1")
+		return nil
+	}})
+	registry.MustAddAdvice("Import", Advice{Type: After, Handler: func(c *Context) error {
+		batchAfter++
+		return nil
+	}})
+
+	var transactions []any
+	registry.MustAddAdvice(BatchItemKey("Import"), Advice{Type: Before, Handler: func(c *Context) error {
+		itemBefore++
+		tx, _ := c.BatchContext().GetMetadataVal("tx")
+		transactions = append(transactions, tx)
+		return nil
+	}})
+
+	var imported []any
+	err := registry.Batch("Import").Run([]any{"a", "b", "c"}, func(c *Context) {
+		imported = append(imported, c.Arg(0))
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if batchBefore != 1 || batchAfter != 1 {
+		t.Errorf("expected batch advice to run once, got %d Before and %d After", batchBefore, batchAfter)
+	}
+	if itemBefore != 3 || len(imported) != 3 {
+		t.Errorf("expected item advice and target to run 3 times, got %d and %d", itemBefore, len(imported))
+	}
+	for i, tx := range transactions {
+		if tx != "tx-1" {
+			t.Errorf("item %d: expected the batch-scoped metadata, got %v", i, tx)
+		}
+	}
+}
+
+func TestRegistry_Batch_JoinsItemErrors(t *testing.T) {
+	registry := NewRegistry()
+	errOdd := errors.New("odd item")
+
+	processed := 0
+	err := registry.Batch("Process").Run([]any{1, 2, 3}, func(c *Context) {
+		processed++
+		if c.Arg(0).(int)%2 == 1 {
+			c.Error = errOdd
+		}
+	})
+
+	if processed != 3 {
+		t.Errorf("expected failures not to stop the batch, processed %d", processed)
+	}
+	if !errors.Is(err, errOdd) {
+		t.Errorf("expected the item errors to be joined, got %v", err)
+	}
+	if NewContext("x").BatchContext() != nil {
+		t.Error("expected no batch context outside of batches")
+	}
+}
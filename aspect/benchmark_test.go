@@ -2,6 +2,9 @@ package aspect
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"sync"
 	"testing"
 	"time"
@@ -644,3 +647,161 @@ func Benchmark_RealWorldExample(b *testing.B) {
 		}
 	})
 }
+
+// Benchmark_ChainLookup compares the memoized chain lookup of wrapped functions
+// with a fresh registry lookup on every call
+func Benchmark_ChainLookup(b *testing.B) {
+	reg := NewRegistry()
+	reg.MustRegister("lookup")
+	reg.MustAddAdvice("lookup", Advice{
+		Type:    Before,
+		Handler: func(c *Context) error { return nil },
+	})
+
+	b.Run("Memoized", func(b *testing.B) {
+		site := newWrapSite(reg, "lookup")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = site.chain()
+		}
+	})
+
+	b.Run("Uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = reg.GetAdviceChain("lookup")
+		}
+	})
+
+	b.Run("WrappedCall", func(b *testing.B) {
+		wrapped := Wrap1R(reg, "lookup", func(x int) int { return x })
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrapped(i)
+		}
+	})
+}
+
+// Benchmark_LazyArgs compares eager argument formatting with LazyArgs
+// when the log level filters the message out
+func Benchmark_LazyArgs(b *testing.B) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	payload := make([]int, 256)
+	c := NewContext("log", "user-42", payload, map[string]int{"retries": 3})
+
+	b.Run("Eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			logger.Debug("calling", "args", fmt.Sprintf("%v", c.Args))
+		}
+	})
+
+	b.Run("Lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			logger.Debug("calling", "args", LazyArgs(c))
+		}
+	})
+}
+
+// Benchmark_OneBeforeOneAfter measures the most common shape: a single Before and a single
+// After advice (logging)
+//
+//	CopyAndSort	 980532	      1263 ns/op	     775 B/op	      13 allocs/op
+//	FastPath	1551357	       810.5 ns/op	     567 B/op	       9 allocs/op
+func Benchmark_OneBeforeOneAfter(b *testing.B) {
+	reg := NewRegistry()
+	reg.MustRegister("fn")
+	reg.MustAddAdvice("fn", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+	reg.MustAddAdvice("fn", Advice{Type: After, Handler: func(c *Context) error { return nil }})
+
+	wrapped := Wrap1R(reg, "fn", func(a int) int { return a + 1 })
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = wrapped(i)
+	}
+}
+
+// Benchmark_FuncIDDispatch compares key-based with ID-based dispatch. In the steady state both
+// use the memoized lookups and perform alike; the ID only avoids the map lookup once the
+// registry changed
+func Benchmark_FuncIDDispatch(b *testing.B) {
+	reg := NewRegistry()
+	id, _ := reg.RegisterID("dispatch")
+	reg.MustAddAdvice("dispatch", Advice{
+		Type:    Before,
+		Handler: func(c *Context) error { return nil },
+	})
+
+	b.Run("KeyLookup", func(b *testing.B) {
+		site := newWrapSite(reg, "dispatch")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reg.generation.Add(1) // Defeat memoization to measure the lookup itself
+			_, _ = site.chain()
+		}
+	})
+
+	b.Run("IDLookup", func(b *testing.B) {
+		site := reg.newIDSite(id)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reg.generation.Add(1)
+			_, _ = site.chain()
+		}
+	})
+
+	b.Run("KeyWrappedCall", func(b *testing.B) {
+		wrapped := Wrap1R(reg, "dispatch", func(x int) int { return x })
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrapped(i)
+		}
+	})
+
+	b.Run("IDWrappedCall", func(b *testing.B) {
+		wrapped := Wrap1RByID(reg, id, func(x int) int { return x })
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrapped(i)
+		}
+	})
+}
+
+// Benchmark_FrozenChain compares a mutable chain, copied and sorted on every phase, with a
+// frozen one read as it is
+func Benchmark_FrozenChain(b *testing.B) {
+	setup := func(freeze bool) func(int) int {
+		reg := NewRegistry()
+		reg.MustRegister("frozen")
+		for priority := 0; priority < 3; priority++ {
+			reg.MustAddAdvice("frozen", Advice{
+				Type:     Before,
+				Priority: priority,
+				Handler:  func(c *Context) error { return nil },
+			})
+		}
+		if freeze {
+			reg.FreezeAll()
+		}
+		return Wrap1R(reg, "frozen", func(x int) int { return x })
+	}
+
+	b.Run("Mutable", func(b *testing.B) {
+		wrapped := setup(false)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrapped(i)
+		}
+	})
+
+	b.Run("Frozen", func(b *testing.B) {
+		wrapped := setup(true)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = wrapped(i)
+		}
+	})
+}
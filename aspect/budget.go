@@ -0,0 +1,79 @@
+// Package aspect - budget provides splitting a time budget between the Before phase and the target
+package aspect
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// budgetContextKey is the metadata key holding the target's context derived by BudgetedTimeout.
+const budgetContextKey = "aspect.budgetContext"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// BudgetedTimeout returns a Before advice pair splitting a total time budget between the Before
+// phase and the target, so a slow Before advice cannot consume the time meant for the target:
+//
+//	for _, advice := range aspect.BudgetedTimeout(time.Second, 0.2) {
+//		registry.MustAddAdvice("GetUser", advice)
+//	}
+//
+// The first advice runs first and gives the Before phase a context expiring after
+// total*beforeFraction. The second runs last in the Before phase and replaces it with a context
+// expiring once total elapsed since the first, which Around advice and the target then see.
+// Deadlines of the incoming context still apply when earlier.
+//
+// Before advice outliving its slice sees its context done and may give up; Before advice not yet
+// started then is skipped, so the target still runs with the rest of the budget. Once the whole
+// budget or the incoming context is done, the call fails with the context's error as usual.
+// beforeFraction is clamped to [0, 1].
+func BudgetedTimeout(total time.Duration, beforeFraction float64) []Advice {
+	beforeFraction = max(0, min(1, beforeFraction))
+
+	return []Advice{
+		{
+			Type:     Before,
+			Priority: math.MaxInt,
+			Name:     "budgetedTimeout",
+			Handler: func(c *Context) error {
+				targetCtx, cancelTarget := context.WithTimeout(c.Context(), total)
+				beforeCtx, cancelBefore := context.WithTimeout(targetCtx, time.Duration(float64(total)*beforeFraction))
+				c.OnComplete(func(*Context) {
+					cancelBefore()
+					cancelTarget()
+				})
+
+				c.SetMetadataVal(budgetContextKey, targetCtx)
+				c.ctx = beforeCtx
+				return nil
+			},
+		},
+		{
+			Type:     Before,
+			Priority: math.MinInt,
+			Name:     "budgetedTimeout",
+			Handler: func(c *Context) error {
+				val, _ := c.GetMetadataVal(budgetContextKey)
+				if targetCtx, ok := val.(context.Context); ok {
+					c.ctx = targetCtx
+				}
+				return nil
+			},
+			boundary: true,
+		},
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// beforeSliceExpired reports whether the Before slice of a BudgetedTimeout expired while the
+// target's budget is still live.
+func (c *Context) beforeSliceExpired() bool {
+	val, _ := c.GetMetadataVal(budgetContextKey)
+	targetCtx, ok := val.(context.Context)
+	return ok && targetCtx.Err() == nil && errors.Is(c.Context().Err(), context.DeadlineExceeded)
+}
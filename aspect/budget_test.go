@@ -0,0 +1,118 @@
+// Package aspect - budget_test validates splitting a time budget between Before advice and the target
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestBudgetedTimeout(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	for _, advice := range BudgetedTimeout(time.Second, 0.02) {
+		registry.MustAddAdvice("GetUser", advice)
+	}
+
+	// A slow Before advice giving up once its slice expired
+	var beforeErr error
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		select {
+		case <-c.Context().Done():
+			beforeErr = c.Context().Err()
+		case <-time.After(time.Second):
+		}
+		return nil
+	}})
+
+	var targetErr error
+	var targetRemaining time.Duration
+	getUser := Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id string) (string, error) {
+		targetErr = ctx.Err()
+		deadline, _ := ctx.Deadline()
+		targetRemaining = time.Until(deadline)
+		return "user-" + id, nil
+	})
+
+	start := time.Now()
+	user, err := getUser(context.Background(), "42")
+	if err != nil || user != "user-42" {
+		t.Fatalf("unexpected result: %q, %v", user, err)
+	}
+	if !errors.Is(beforeErr, context.DeadlineExceeded) {
+		t.Errorf("expected the Before phase to be cancelled, got %v", beforeErr)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the Before phase to give up after its slice, took %v", elapsed)
+	}
+	if targetErr != nil {
+		t.Errorf("expected the target's context to be live, got %v", targetErr)
+	}
+	if targetRemaining < 500*time.Millisecond {
+		t.Errorf("expected the target to get the rest of the budget, got %v", targetRemaining)
+	}
+}
+
+func TestBudgetedTimeout_KeepsEarlierDeadline(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	for _, advice := range BudgetedTimeout(time.Hour, 0.5) {
+		registry.MustAddAdvice("GetUser", advice)
+	}
+
+	var targetDeadline time.Time
+	getUser := Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id string) (string, error) {
+		targetDeadline, _ = ctx.Deadline()
+		return id, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	if _, err := getUser(ctx, "42"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !targetDeadline.Equal(deadline) {
+		t.Errorf("expected the incoming deadline %v, got %v", deadline, targetDeadline)
+	}
+}
+
+func TestBudgetedTimeout_SkipsRemainingBefore(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	for _, advice := range BudgetedTimeout(time.Second, 0.02) {
+		registry.MustAddAdvice("GetUser", advice)
+	}
+
+	// The first Before advice overruns its slice without giving up, the second one is skipped
+	var order []string
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 2, Handler: func(c *Context) error {
+		order = append(order, "slow")
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 1, Handler: func(c *Context) error {
+		order = append(order, "late")
+		return nil
+	}})
+
+	var targetErr error
+	getUser := Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id string) (string, error) {
+		targetErr = ctx.Err()
+		return "user-" + id, nil
+	})
+
+	user, err := getUser(context.Background(), "42")
+	if err != nil || user != "user-42" {
+		t.Fatalf("expected the target to run with the rest of the budget, got %q, %v", user, err)
+	}
+	if targetErr != nil {
+		t.Errorf("expected the target's context to be live, got %v", targetErr)
+	}
+	if len(order) != 1 || order[0] != "slow" {
+		t.Errorf("expected the remaining Before advice to be skipped, got %v", order)
+	}
+}
@@ -0,0 +1,63 @@
+// Package aspect - cache provides the store used by advice keeping results across invocations
+package aspect
+
+import (
+	"sync"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// CacheStore stores values shared across invocations, such as the outcomes kept by Idempotent.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (any, bool)
+	Set(key string, val any, ttl time.Duration) // Set stores val for ttl; zero or negative keeps it forever.
+}
+
+// MemoryCacheStore is an in-memory CacheStore. Expired entries are removed lazily on access.
+type MemoryCacheStore struct {
+	entries map[string]memoryCacheEntry
+	mu      sync.Mutex
+}
+
+// memoryCacheEntry is a value of the MemoryCacheStore with its expiry (zero means no expiry).
+type memoryCacheEntry struct {
+	val       any
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore creates an empty in-memory cache store.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]memoryCacheEntry)}
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Get returns the value stored under key, if present and not expired.
+func (store *MemoryCacheStore) Get(key string) (any, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	entry, exists := store.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if !entry.expiresAt.IsZero() && now().After(entry.expiresAt) {
+		delete(store.entries, key)
+		return nil, false
+	}
+	return entry.val, true
+}
+
+// Set stores val under key for ttl.
+func (store *MemoryCacheStore) Set(key string, val any, ttl time.Duration) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	entry := memoryCacheEntry{val: val}
+	if ttl > 0 {
+		entry.expiresAt = now().Add(ttl)
+	}
+	store.entries[key] = entry
+}
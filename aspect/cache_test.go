@@ -0,0 +1,30 @@
+// Package aspect - cache_test validates the in-memory cache store
+package aspect
+
+import (
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+	store.Set("forever", 1, 0)
+	store.Set("short", 2, 10*time.Millisecond)
+
+	if val, ok := store.Get("short"); !ok || val != 2 {
+		t.Errorf("expected 2, got %v (found=%v)", val, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := store.Get("short"); ok {
+		t.Error("expected entry to expire")
+	}
+	if val, ok := store.Get("forever"); !ok || val != 1 {
+		t.Errorf("expected entry without ttl to stay, got %v (found=%v)", val, ok)
+	}
+	if _, ok := store.Get("missing"); ok {
+		t.Error("expected missing key not to be found")
+	}
+}
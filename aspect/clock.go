@@ -0,0 +1,48 @@
+// Package aspect - clock provides the time source of the built-in time-based helpers
+package aspect
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// Clock is a source of the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// clockBox holds a Clock so implementations of different types can be swapped atomically.
+type clockBox struct {
+	clock Clock
+}
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// currentClock is the clock used by the built-in helpers.
+var currentClock atomic.Pointer[clockBox]
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetClock sets the clock used by the built-in time-based helpers, such as the expiry of
+// MemoryCacheStore entries and the durations recorded by stats. Tests inject a fake clock to
+// advance time precisely instead of sleeping. Passing nil restores the real clock.
+// The clock is package-wide: tests replacing it must not run in parallel with others.
+func SetClock(clock Clock) {
+	if clock == nil {
+		currentClock.Store(nil)
+		return
+	}
+	currentClock.Store(&clockBox{clock: clock})
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// now returns the current time of the configured clock.
+func now() time.Time {
+	if box := currentClock.Load(); box != nil {
+		return box.clock.Now()
+	}
+	return time.Now()
+}
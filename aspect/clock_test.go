@@ -0,0 +1,68 @@
+// Package aspect - clock_test validates clock injection into the time-based helpers
+package aspect
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestSetClock_CacheExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	SetClock(clock)
+	defer SetClock(nil)
+
+	store := NewMemoryCacheStore()
+	store.Set("session", "abc", time.Hour)
+
+	clock.Advance(59 * time.Minute)
+	if _, ok := store.Get("session"); !ok {
+		t.Fatal("expected entry to be present before the ttl")
+	}
+
+	clock.Advance(2 * time.Minute)
+	if _, ok := store.Get("session"); ok {
+		t.Error("expected entry to expire after the ttl without sleeping")
+	}
+}
+
+func TestSetClock_StatsDuration(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	SetClock(clock)
+	defer SetClock(nil)
+
+	registry := NewRegistry()
+	registry.EnableStats(true)
+	Wrap0(registry, "Slow", func() { clock.Advance(3 * time.Second) })()
+
+	if stats := registry.Stats("Slow"); stats.TotalDuration != 3*time.Second {
+		t.Errorf("expected a duration of exactly 3s, got %v", stats.TotalDuration)
+	}
+
+	SetClock(nil)
+	if since := time.Since(now()); since < 0 || since > time.Minute {
+		t.Errorf("expected the real clock to be restored, got offset %v", since)
+	}
+}
@@ -0,0 +1,42 @@
+// Package aspect - condition provides combinators for advice conditions
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// And returns an advice condition (see Advice.Condition) holding when all conds hold, e.g.
+// "admin and not cached":
+//
+//	Condition: aspect.And(isAdmin, aspect.Not(isCached)),
+//
+// Conditions are evaluated in order and evaluation stops at the first one not holding.
+// Without conditions it always holds.
+func And(conds ...func(c *Context) bool) func(c *Context) bool {
+	return func(c *Context) bool {
+		for _, cond := range conds {
+			if !cond(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns an advice condition holding when any of conds holds. Conditions are evaluated in
+// order and evaluation stops at the first one holding. Without conditions it never holds.
+func Or(conds ...func(c *Context) bool) func(c *Context) bool {
+	return func(c *Context) bool {
+		for _, cond := range conds {
+			if cond(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns an advice condition holding when cond does not.
+func Not(cond func(c *Context) bool) func(c *Context) bool {
+	return func(c *Context) bool {
+		return !cond(c)
+	}
+}
@@ -0,0 +1,83 @@
+// Package aspect - condition_test validates the advice condition combinators
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// countingCond returns a condition with a fixed result counting its evaluations.
+func countingCond(result bool, evaluations *int) func(c *Context) bool {
+	return func(c *Context) bool {
+		*evaluations++
+		return result
+	}
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAnd(t *testing.T) {
+	c := NewContext("Fn")
+	var evaluations int
+	yes, no := countingCond(true, &evaluations), countingCond(false, &evaluations)
+
+	if !And(yes, yes)(c) || And(yes, no)(c) || !And()(c) {
+		t.Error("expected And to hold only when all conditions hold")
+	}
+
+	evaluations = 0
+	And(no, yes, yes)(c)
+	if evaluations != 1 {
+		t.Errorf("expected And to stop at the first false condition, evaluated %d", evaluations)
+	}
+}
+
+func TestOr(t *testing.T) {
+	c := NewContext("Fn")
+	var evaluations int
+	yes, no := countingCond(true, &evaluations), countingCond(false, &evaluations)
+
+	if !Or(no, yes)(c) || Or(no, no)(c) || Or()(c) {
+		t.Error("expected Or to hold when any condition holds")
+	}
+
+	evaluations = 0
+	Or(yes, no, no)(c)
+	if evaluations != 1 {
+		t.Errorf("expected Or to stop at the first true condition, evaluated %d", evaluations)
+	}
+}
+
+func TestNot(t *testing.T) {
+	c := NewContext("Fn")
+	var evaluations int
+
+	if Not(countingCond(true, &evaluations))(c) || !Not(countingCond(false, &evaluations))(c) {
+		t.Error("expected Not to negate the condition")
+	}
+}
+
+func TestConditions_AsAdviceCondition(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Delete")
+
+	isAdmin := func(c *Context) bool { return c.Arg(0) == "admin" }
+	isCached := func(c *Context) bool { return c.Arg(1) == true }
+
+	var fired int
+	registry.MustAddAdvice("Delete", Advice{
+		Type:      Before,
+		Condition: And(isAdmin, Not(isCached)),
+		Handler: func(c *Context) error {
+			fired++
+			return nil
+		},
+	})
+
+	deleteFn := Wrap2(registry, "Delete", func(user string, cached bool) {})
+	deleteFn("admin", false)
+	deleteFn("admin", true)
+	deleteFn("guest", false)
+	if fired != 1 {
+		t.Errorf("expected the advice to fire for the non-cached admin call only, fired %d times", fired)
+	}
+}
@@ -4,7 +4,10 @@ package aspect
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"time"
 )
 
 // -------------------------------------------- Types --------------------------------------------
@@ -17,9 +20,25 @@ type Context struct {
 	Results      []any           // Results contains the function return values (populated after execution).
 	Error        error           // Error holds any error returned by the function.
 	PanicValue   any             // PanicValue holds the recovered panic value if a panic occurred.
-	Metadata     map[string]any  // Metadata allows storing custom key-value pairs for advice communication.
+	Metadata     map[string]any  // Metadata allows storing custom key-value pairs for advice communication (unused with a custom MetadataStore).
 	Skipped      bool            // Skipped indicates if the target function execution should be skipped (set by Around advice).
 	ctx          context.Context // Context allows propagation of cancellation signals and deadlines through the AOP system.
+	registry     *Registry       // registry is the registry executing this invocation (nil for standalone chains).
+	lookup       *cachedChain    // lookup holds the memoized registry lookups of the invocation (nil for standalone chains).
+	targetRan    bool            // targetRan is set by the engine when the target function is invoked.
+	store        MetadataStore   // store replaces the Metadata map when a custom MetadataStore is configured.
+	maxMetadata  int             // maxMetadata caps the number of metadata entries (0 means unlimited).
+	argsString   *string         // argsString caches the formatted arguments (see ArgsString).
+	onComplete   []func(*Context)
+	proceed      func() error             // proceed runs the nested Around advice and the target while Around advice runs (see Proceed).
+	shadow       []ShadowDecision         // shadow records the decisions of shadow advice (see ShadowDecisions).
+	meta         map[string]any           // meta holds the response metadata contributed by advice (see AddMeta).
+	warnings     []string                 // warnings holds the response warnings contributed by advice (see AddWarning).
+	phaseTimings map[string]time.Duration // phaseTimings is non-nil when phase timing is enabled (see PhaseTimings).
+	traceRegions bool                     // traceRegions is set when runtime/trace regions are emitted (see SetRuntimeTrace).
+	start        time.Time                // start is when the invocation started (zero for standalone contexts).
+	elapsed      time.Duration            // elapsed is the duration of the completed invocation.
+	finished     bool                     // finished is set once the invocation completed.
 	mu           sync.RWMutex
 }
 
@@ -41,6 +60,49 @@ func NewContextWithContext(ctx context.Context, functionName FuncKey, args ...an
 
 // -------------------------------------------- Public Functions --------------------------------------------
 
+// Clone returns a copy of the context. Args, Results and Metadata are copied,
+// so the copy can be modified without affecting the original.
+func (c *Context) Clone() *Context {
+	c.mu.RLock()
+	metadata := make(map[string]any, len(c.Metadata))
+	for key, val := range c.Metadata {
+		metadata[key] = val
+	}
+	meta, warnings := maps.Clone(c.meta), slices.Clone(c.warnings)
+	c.mu.RUnlock()
+
+	var store MetadataStore
+	if c.store != nil && c.registry != nil {
+		if store = c.newMetadataStore(); store != nil {
+			c.store.Range(func(key string, val any) bool {
+				store.Set(key, val)
+				return true
+			})
+		}
+	}
+
+	return &Context{
+		FunctionName: c.FunctionName,
+		Args:         append([]any(nil), c.Args...),
+		Results:      append([]any(nil), c.Results...),
+		Error:        c.Error,
+		PanicValue:   c.PanicValue,
+		Metadata:     metadata,
+		Skipped:      c.Skipped,
+		ctx:          c.ctx,
+		registry:     c.registry,
+		lookup:       c.lookup,
+		targetRan:    c.targetRan,
+		store:        store,
+		maxMetadata:  c.maxMetadata,
+		meta:         meta,
+		warnings:     warnings,
+		start:        c.start,
+		elapsed:      c.elapsed,
+		finished:     c.finished,
+	}
+}
+
 // SetResult sets a return value at the specified index.
 func (c *Context) SetResult(index int, value any) {
 	if index < 0 {
@@ -62,6 +124,43 @@ func (c *Context) GetResult(index int) any {
 	return c.Results[index]
 }
 
+// Result returns the return value at the specified index, or nil if it is not set.
+// Together with Err and Panicked it forms the read-only result API for consuming an execution.
+func (c *Context) Result(index int) any {
+	return c.GetResult(index)
+}
+
+// Err returns the final error of the execution, including errors raised by advice.
+func (c *Context) Err() error {
+	return c.Error
+}
+
+// Panicked reports whether the execution panicked and returns the recovered panic value.
+func (c *Context) Panicked() (any, bool) {
+	return c.PanicValue, c.PanicValue != nil
+}
+
+// TargetRan reports whether the target function was invoked. It is false when the target was
+// skipped by Around advice or never reached because of a Before/Around error or cancellation.
+// A target that panicked did run.
+func (c *Context) TargetRan() bool {
+	return c.targetRan
+}
+
+// Elapsed returns the duration of the invocation, advice included: the time elapsed so far while
+// it runs (e.g. in After advice), the total duration once it completed. It is zero for contexts
+// not created by the engine.
+func (c *Context) Elapsed() time.Duration {
+	switch {
+	case c.finished:
+		return c.elapsed
+	case c.start.IsZero():
+		return 0
+	default:
+		return now().Sub(c.start)
+	}
+}
+
 // HasPanic returns true if a panic was recovered during execution.
 func (c *Context) HasPanic() bool {
 	return c.PanicValue != nil
@@ -73,14 +172,35 @@ func (c *Context) String() string {
 		c.FunctionName, c.Args, c.Results, c.Error, c.PanicValue)
 }
 
+// SetMetadataVal stores a metadata value under key.
+// When the registry caps the number of entries (see Registry.SetMaxMetadataEntries),
+// adding a new key beyond the limit is dropped and reported as ErrMetadataLimit.
 func (c *Context) SetMetadataVal(key string, val any) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	if c.store != nil {
+		if c.maxMetadata > 0 && !c.storeAccepts(key) {
+			c.reportMetadataLimit(key)
+			return
+		}
+		c.store.Set(key, val)
+		return
+	}
 
+	c.mu.Lock()
+	if _, exists := c.Metadata[key]; !exists && c.maxMetadata > 0 && len(c.Metadata) >= c.maxMetadata {
+		c.mu.Unlock()
+		c.reportMetadataLimit(key)
+		return
+	}
 	c.Metadata[key] = val
+	c.mu.Unlock()
 }
 
+// GetMetadataVal retrieves the metadata value stored under key.
 func (c *Context) GetMetadataVal(key string) (any, bool) {
+	if c.store != nil {
+		return c.store.Get(key)
+	}
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -88,6 +208,16 @@ func (c *Context) GetMetadataVal(key string) (any, bool) {
 	return val, exists
 }
 
+// OnComplete registers fn to run once the invocation has completed, after After advice and with
+// the final results and error in place. Callbacks run in reverse registration order, like defers.
+// Registering from advice running on a clone (parallel After advice) has no effect.
+func (c *Context) OnComplete(fn func(c *Context)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onComplete = append(c.onComplete, fn)
+}
+
 // Context returns the underlying context.
 //
 // The returned context is always non-nil; it defaults to the
@@ -98,3 +228,63 @@ func (c *Context) Context() context.Context {
 	}
 	return context.Background()
 }
+
+// RemainingTime returns the time left until the deadline of the invocation's context, negative
+// once it passed, e.g. for After advice degrading expensive audit or flush work when the budget
+// is blown. Context deadlines are set and fire on the wall clock, so the time left is measured on
+// it too, not on the clock configured with SetClock. ok is false if the context has no deadline.
+func (c *Context) RemainingTime() (remaining time.Duration, ok bool) {
+	deadline, ok := c.Context().Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// adviceFor returns the advice of the given type to run for the invocation, merging the
+// registry's global and pattern advice with the chain's own advice.
+func (c *Context) adviceFor(chain *AdviceChain, adviceType AdviceType) []Advice {
+	if c.lookup != nil {
+		return mergeShared(chain, c.lookup.shared, adviceType)
+	}
+	return c.registry.adviceFor(c.FunctionName, chain, adviceType)
+}
+
+// priorityOverrides returns the priority overrides of the invocation, or nil if there are none.
+func (c *Context) priorityOverrides() map[string]int {
+	if c.lookup != nil {
+		return c.lookup.overrides
+	}
+	return c.registry.priorityOverrides(c.FunctionName)
+}
+
+// newMetadataStore creates a metadata store for the invocation, or nil to use the default map.
+func (c *Context) newMetadataStore() MetadataStore {
+	if c.lookup == nil {
+		return c.registry.newMetadataStore()
+	}
+	if c.lookup.newStore == nil {
+		return nil
+	}
+	return c.lookup.newStore()
+}
+
+// finish records the duration of the completed invocation.
+func (c *Context) finish(elapsed time.Duration) {
+	c.elapsed = elapsed
+	c.finished = true
+}
+
+// complete runs the callbacks registered with OnComplete.
+func (c *Context) complete() {
+	c.mu.Lock()
+	callbacks := c.onComplete
+	c.onComplete = nil
+	c.mu.Unlock()
+
+	for i := len(callbacks) - 1; i >= 0; i-- {
+		callbacks[i](c)
+	}
+}
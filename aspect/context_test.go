@@ -3,6 +3,7 @@ package aspect
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -138,6 +139,63 @@ func TestContextDeadline(t *testing.T) {
 	}
 }
 
+// TestContextDeadline_ShortCircuitsBefore verifies that a deadline expiring during Before advice
+// skips the remaining Before advice and the target, returns the context error unwrapped and
+// still runs After advice
+func TestContextDeadline_ShortCircuitsBefore(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("TestContextDeadline_ShortCircuitsBefore")
+
+	var executionOrder []string
+	registry.MustAddAdvice("TestContextDeadline_ShortCircuitsBefore", Advice{
+		Type:     Before,
+		Priority: 100,
+		Handler: func(c *Context) error {
+			executionOrder = append(executionOrder, "slow-before")
+			<-c.Context().Done()
+			return nil
+		},
+	})
+	registry.MustAddAdvice("TestContextDeadline_ShortCircuitsBefore", Advice{
+		Type:     Before,
+		Priority: 50,
+		Handler: func(c *Context) error {
+			executionOrder = append(executionOrder, "before")
+			return nil
+		},
+	})
+	registry.MustAddAdvice("TestContextDeadline_ShortCircuitsBefore", Advice{
+		Type: After,
+		Handler: func(c *Context) error {
+			executionOrder = append(executionOrder, "after")
+			return nil
+		},
+	})
+
+	wrappedFn := Wrap0ECtx(registry, "TestContextDeadline_ShortCircuitsBefore", func(ctx context.Context) error {
+		executionOrder = append(executionOrder, "target")
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := wrappedFn(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	expectedOrder := []string{"slow-before", "after"}
+	if len(executionOrder) != len(expectedOrder) {
+		t.Fatalf("expected %v, got %v", expectedOrder, executionOrder)
+	}
+	for i, expected := range expectedOrder {
+		if executionOrder[i] != expected {
+			t.Errorf("step %d: expected '%s', got '%s'", i, expected, executionOrder[i])
+		}
+	}
+}
+
 // TestContextValues verifies that context values propagate through advice
 func TestContextValues(t *testing.T) {
 	registry := NewRegistry()
@@ -280,3 +338,260 @@ func TestContextPropagationThroughAllAdviceTypes(t *testing.T) {
 		}
 	}
 }
+
+// TestRegistryDefaultTimeout verifies that the registry default timeout bounds context-aware calls
+func TestRegistryDefaultTimeout(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("TestDefaultTimeout")
+	registry.SetDefaultTimeout(20 * time.Millisecond)
+
+	var adviceHadDeadline bool
+	registry.MustAddAdvice("TestDefaultTimeout", Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			_, adviceHadDeadline = c.Context().Deadline()
+			return nil
+		},
+	})
+
+	slowFn := func(ctx context.Context) error {
+		select {
+		case <-time.After(time.Second):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	wrappedFn := Wrap0ECtx(registry, "TestDefaultTimeout", slowFn)
+
+	start := time.Now()
+	err := wrappedFn(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected default timeout to stop the call early, took %v", elapsed)
+	}
+	if !adviceHadDeadline {
+		t.Error("expected advice to see the default deadline")
+	}
+
+	// An earlier incoming deadline is kept
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	expected, _ := ctx.Deadline()
+
+	var seen time.Time
+	Wrap0Ctx(registry, "TestDefaultTimeout", func(ctx context.Context) {
+		seen, _ = ctx.Deadline()
+	})(ctx)
+	if !seen.Equal(expected) {
+		t.Errorf("expected incoming deadline %v to be kept, got %v", expected, seen)
+	}
+}
+
+// TestContextClone verifies that a cloned context is independent from the original
+func TestContextClone(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "key", "value")
+	c := NewContextWithContext(ctx, "TestClone", 1, 2)
+	c.SetResult(0, "result")
+	c.SetMetadataVal("meta", "original")
+
+	clone := c.Clone()
+	clone.SetArg(0, 100)
+	clone.SetResult(0, "changed")
+	clone.SetMetadataVal("meta", "changed")
+
+	if c.Args[0] != 1 || c.Results[0] != "result" {
+		t.Errorf("expected original args/results to be unchanged, got %v / %v", c.Args, c.Results)
+	}
+	if val, _ := c.GetMetadataVal("meta"); val != "original" {
+		t.Errorf("expected original metadata to be unchanged, got %v", val)
+	}
+	if clone.FunctionName != "TestClone" || clone.Context().Value("key") != "value" {
+		t.Error("expected clone to keep function name and context")
+	}
+}
+
+// TestWrap1RECtxOut verifies that a context returned by the target is exposed to later advice and the caller
+func TestWrap1RECtxOut(t *testing.T) {
+	type sessionKey struct{}
+
+	registry := NewRegistry()
+	registry.MustRegister("OpenSession")
+
+	var observed any
+	registry.MustAddAdvice("OpenSession", Advice{
+		Type: After,
+		Handler: func(c *Context) error {
+			observed = c.Context().Value(sessionKey{})
+			return nil
+		},
+	})
+
+	openSession := Wrap1RECtxOut(registry, "OpenSession", func(ctx context.Context, user string) (context.Context, int, error) {
+		return context.WithValue(ctx, sessionKey{}, "session-"+user), 42, nil
+	})
+
+	ctx, id, err := openSession(context.Background(), "alice")
+	if err != nil || id != 42 {
+		t.Fatalf("unexpected result: %d, %v", id, err)
+	}
+	if ctx.Value(sessionKey{}) != "session-alice" {
+		t.Errorf("expected the enriched context to be returned, got %v", ctx.Value(sessionKey{}))
+	}
+	if observed != "session-alice" {
+		t.Errorf("expected After advice to observe the enriched context, got %v", observed)
+	}
+
+	// A skipped target leaves the incoming context untouched
+	registry.MustAddAdvice("OpenSession", Advice{
+		Type: Around,
+		Handler: func(c *Context) error {
+			c.Skipped = true
+			c.SetResult(0, 7)
+			return nil
+		},
+	})
+
+	incoming := context.Background()
+	ctx, id, _ = openSession(incoming, "bob")
+	if ctx != incoming || id != 7 {
+		t.Errorf("expected incoming context and skip result 7, got %v, %d", ctx, id)
+	}
+}
+
+// TestWrap1RECtxOut_DefaultTimeout verifies the returned context outlives the internal timeout context
+func TestWrap1RECtxOut_DefaultTimeout(t *testing.T) {
+	type sessionKey struct{}
+
+	registry := NewRegistry()
+	registry.MustRegister("OpenSession")
+	registry.SetDefaultTimeout(time.Second)
+
+	openSession := Wrap1RECtxOut(registry, "OpenSession", func(ctx context.Context, user string) (context.Context, int, error) {
+		return context.WithValue(ctx, sessionKey{}, "session-"+user), 42, nil
+	})
+
+	incoming, cancel := context.WithCancel(context.Background())
+	outCtx, _, err := openSession(incoming, "alice")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if outCtx.Err() != nil {
+		t.Errorf("expected the returned context to be live, got %v", outCtx.Err())
+	}
+	if outCtx.Value(sessionKey{}) != "session-alice" {
+		t.Errorf("expected the enriched value, got %v", outCtx.Value(sessionKey{}))
+	}
+	if _, hasDeadline := outCtx.Deadline(); hasDeadline {
+		t.Error("expected the internal timeout's deadline not to leak into the returned context")
+	}
+
+	// Cancellation still follows the incoming context
+	cancel()
+	if !errors.Is(outCtx.Err(), context.Canceled) {
+		t.Errorf("expected the returned context to be cancelled with the incoming one, got %v", outCtx.Err())
+	}
+}
+
+func TestWrap1RECtxOut_EngineValuesDoNotLeak(t *testing.T) {
+	type sessionKey struct{}
+
+	registry := NewRegistry()
+	registry.MustRegister("Auth")
+	registry.MustAddAdvice("Auth", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+	registry.SetRecursionPolicy("Auth", RecursionError)
+	registry.SetExposeContext(true)
+
+	auth := Wrap1RECtxOut(registry, "Auth", func(ctx context.Context, user string) (context.Context, int, error) {
+		return context.WithValue(ctx, sessionKey{}, "session-"+user), 1, nil
+	})
+
+	ctx, _, err := auth(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := FromContext(ctx); ok {
+		t.Error("expected the invocation's Context not to be exposed through the returned context")
+	}
+	if ctx.Value(sessionKey{}) != "session-alice" {
+		t.Errorf("expected the enriched value, got %v", ctx.Value(sessionKey{}))
+	}
+
+	// A later, sequential call with the returned context is not a recursive call
+	if _, _, err := auth(ctx, "bob"); err != nil {
+		t.Errorf("expected a sequential call to succeed, got %v", err)
+	}
+}
+
+// TestContextOnComplete verifies completion callbacks run after After advice with the final outcome
+func TestContextOnComplete(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Complete")
+
+	var order []string
+	registry.MustAddAdvice("Complete", Advice{Type: Before, Handler: func(c *Context) error {
+		c.OnComplete(func(c *Context) { order = append(order, "first") })
+		c.OnComplete(func(c *Context) {
+			order = append(order, "second")
+			if c.Err() == nil || c.Err().Error() != "failed" {
+				t.Errorf("expected the final error, got %v", c.Err())
+			}
+		})
+		return nil
+	}})
+	registry.MustAddAdvice("Complete", Advice{Type: After, Handler: func(c *Context) error {
+		order = append(order, "after")
+		return nil
+	}})
+
+	_ = Wrap0E(registry, "Complete", func() error { return errors.New("failed") })()
+
+	expected := []string{"after", "second", "first"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestContext_RemainingTime(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Flush")
+
+	var remaining time.Duration
+	var hasDeadline bool
+	registry.MustAddAdvice("Flush", Advice{Type: After, Handler: func(c *Context) error {
+		remaining, hasDeadline = c.RemainingTime()
+		return nil
+	}})
+
+	flush := Wrap0ECtx(registry, "Flush", func(ctx context.Context) error {
+		time.Sleep(15 * time.Millisecond)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_ = flush(ctx)
+	if !hasDeadline || remaining > 0 {
+		t.Errorf("expected a blown budget in After advice, got %v (deadline=%v)", remaining, hasDeadline)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	_ = flush(ctx)
+	if !hasDeadline || remaining <= 59*time.Minute {
+		t.Errorf("expected about an hour left, got %v (deadline=%v)", remaining, hasDeadline)
+	}
+
+	_ = flush(context.Background())
+	if hasDeadline || remaining != 0 {
+		t.Errorf("expected no deadline, got %v (deadline=%v)", remaining, hasDeadline)
+	}
+}
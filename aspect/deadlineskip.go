@@ -0,0 +1,27 @@
+// Package aspect - deadlineskip provides shedding optional advice of calls close to their deadline
+package aspect
+
+import "time"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// DeadlineAwareSkip returns Around advice suppressing the named optional advice (see Suppress) for
+// calls with less than threshold left until the deadline of their context, so calls under time
+// pressure shed non-critical work such as auditing or cache warming while the critical path runs:
+//
+//	registry.MustAddAdvice("GetUser", aspect.Advice{
+//		Type:     aspect.Around,
+//		Priority: 100,
+//		Handler:  aspect.DeadlineAwareSkip(50*time.Millisecond, "audit", "warmCache"),
+//	})
+//
+// It affects the advice running after it: the Around advice of lower priority and the
+// AfterReturning, AfterThrowing and After advice. Calls without a deadline are unaffected.
+func DeadlineAwareSkip(threshold time.Duration, optionalAdviceNames ...string) AdviceFunc {
+	return func(c *Context) error {
+		if remaining, ok := c.RemainingTime(); ok && remaining < threshold {
+			c.ctx = Suppress(c.Context(), optionalAdviceNames...)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+// Package aspect - deadlineskip_test validates shedding optional advice close to the deadline
+package aspect
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestDeadlineAwareSkip(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var audited, logged int
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:     Around,
+		Priority: 100,
+		Handler:  DeadlineAwareSkip(time.Minute, "audit"),
+	})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Name: "audit", Handler: func(c *Context) error {
+		audited++
+		return nil
+	}})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Name: "log", Handler: func(c *Context) error {
+		logged++
+		return nil
+	}})
+
+	getUser := Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id string) (string, error) {
+		return "user-" + id, nil
+	})
+
+	// A tight deadline sheds the optional advice while the critical path runs
+	tight, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	user, err := getUser(tight, "42")
+	if err != nil || user != "user-42" {
+		t.Fatalf("unexpected result: %q, %v", user, err)
+	}
+	if audited != 0 || logged != 1 {
+		t.Errorf("expected only the critical advice to run, got audited %d, logged %d", audited, logged)
+	}
+
+	// A loose deadline or none at all keeps the optional advice
+	loose, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	getUser(loose, "42")
+	getUser(context.Background(), "42")
+	if audited != 2 || logged != 3 {
+		t.Errorf("expected all advice to run, got audited %d, logged %d", audited, logged)
+	}
+}
@@ -0,0 +1,97 @@
+// Package aspect - dot exports the advice wiring of a registry as a Graphviz description
+package aspect
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// phaseOrder lists the advice types in the order their phase runs around the target.
+var phaseOrder = []AdviceType{Before, Around, AfterReturning, AfterThrowing, After}
+
+// adviceTypeNames maps advice types to their display names.
+var adviceTypeNames = map[AdviceType]string{
+	Before:         "Before",
+	After:          "After",
+	Around:         "Around",
+	AfterReturning: "AfterReturning",
+	AfterThrowing:  "AfterThrowing",
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// ExportDOT returns a Graphviz (DOT) description of the registry. Every registered function is a
+// node listing its effective advice (including global and pattern advice) by phase, in execution
+// order, with priority, group and handler name. Render it with e.g. `dot -Tsvg`.
+func (registry *Registry) ExportDOT() string {
+	funcKeys := registry.ListRegistered()
+	sort.Slice(funcKeys, func(i, j int) bool { return funcKeys[i] < funcKeys[j] })
+
+	var sb strings.Builder
+	sb.WriteString("digraph aspect {\n")
+	sb.WriteString("\trankdir=LR;\n")
+	sb.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+
+	for _, funcKey := range funcKeys {
+		chain, err := registry.GetAdviceChain(funcKey)
+		if err != nil {
+			continue // Unregistered concurrently
+		}
+
+		var label strings.Builder
+		label.WriteString(string(funcKey))
+		label.WriteString("\n")
+		for _, adviceType := range phaseOrder {
+			for _, advice := range registry.orderedAdvice(funcKey, chain, adviceType) {
+				label.WriteString(describeAdvice(advice))
+				label.WriteString("\n")
+			}
+		}
+
+		fmt.Fprintf(&sb, "\t%s [label=%s];\n", dotQuote(string(funcKey)), dotLabel(label.String()))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// describeAdvice renders a single advice as "<Type> p=<priority> [group=<group>] <handler>".
+func describeAdvice(advice Advice) string {
+	desc := fmt.Sprintf("%s p=%d", adviceTypeNames[advice.Type], advice.Priority)
+	if advice.Group != "" {
+		desc += " group=" + advice.Group
+	}
+	if name := handlerName(advice.Handler); name != "" {
+		desc += " " + name
+	}
+	return desc
+}
+
+// handlerName returns the fully qualified name of the advice handler, if known.
+func handlerName(handler AdviceFunc) string {
+	if handler == nil {
+		return ""
+	}
+	if fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
+
+// dotQuote quotes s as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// dotLabel quotes a multi-line label, left-aligning every line.
+func dotLabel(s string) string {
+	quoted := dotQuote(s)
+	return strings.ReplaceAll(quoted, "\n", `\l`)
+}
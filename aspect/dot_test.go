@@ -0,0 +1,42 @@
+// Package aspect - dot_test validates the Graphviz export of a registry
+package aspect
+
+import (
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+func auditAdvice(c *Context) error { return nil }
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_ExportDOT(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustRegister(`Say"Hi"`)
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Priority: 5, Handler: auditAdvice})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 100, Group: "auth", Handler: auditAdvice})
+	registry.AddGlobalAdvice(Advice{Type: Before, Priority: 1, Handler: auditAdvice})
+
+	dot := registry.ExportDOT()
+
+	for _, expected := range []string{
+		"digraph aspect {",
+		`"GetUser" [label="GetUser\l`,
+		`Before p=100 group=auth github.com/seyallius/gosaidno/aspect.auditAdvice\l`,
+		`Before p=1 github.com/seyallius/gosaidno/aspect.auditAdvice\l`,
+		`After p=5 github.com/seyallius/gosaidno/aspect.auditAdvice\l`,
+		`"Say\"Hi\""`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("expected DOT output to contain %q, got:\n%s", expected, dot)
+		}
+	}
+
+	// Phases are listed in execution order
+	if strings.Index(dot, "Before p=1 ") > strings.Index(dot, "After p=5") {
+		t.Errorf("expected Before advice to be listed before After advice, got:\n%s", dot)
+	}
+}
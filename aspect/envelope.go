@@ -0,0 +1,47 @@
+// Package aspect - envelope lets advice contribute metadata and warnings to a response envelope
+package aspect
+
+import (
+	"maps"
+	"slices"
+)
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AddMeta adds an entry to the response metadata of the invocation, e.g. the cache status or
+// the timing for APIs returning a standard {data, meta, errors} envelope. Multiple advice can
+// contribute; a later entry with the same key replaces the earlier one. Unlike Metadata, which
+// is how advice communicates, response metadata is meant to be serialized for the caller.
+func (c *Context) AddMeta(key string, v any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.meta == nil {
+		c.meta = make(map[string]any)
+	}
+	c.meta[key] = v
+}
+
+// AddWarning adds a warning to the response of the invocation, e.g. "served stale data".
+func (c *Context) AddWarning(w string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.warnings = append(c.warnings, w)
+}
+
+// Meta returns a copy of the response metadata added with AddMeta, or nil if there is none.
+func (c *Context) Meta() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return maps.Clone(c.meta)
+}
+
+// Warnings returns a copy of the warnings added with AddWarning, in the order they were added.
+func (c *Context) Warnings() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.warnings)
+}
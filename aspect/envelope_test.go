@@ -0,0 +1,50 @@
+// Package aspect - envelope_test validates advice contributions to the response envelope
+package aspect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_EnvelopeContributions(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		c.AddMeta("cache", "miss")
+		return nil
+	}})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Handler: func(c *Context) error {
+		c.AddMeta("timing", c.Elapsed().String())
+		c.AddWarning("served from replica")
+		return nil
+	}})
+
+	c := registry.Execute(context.Background(), "GetUser", func(c *Context) { c.SetResult(0, "alice") })
+
+	meta := c.Meta()
+	if meta["cache"] != "miss" {
+		t.Errorf("expected cache meta from Before advice, got %v", meta["cache"])
+	}
+	if _, ok := meta["timing"].(string); !ok {
+		t.Errorf("expected timing meta from After advice, got %v", meta["timing"])
+	}
+	if warnings := c.Warnings(); !reflect.DeepEqual(warnings, []string{"served from replica"}) {
+		t.Errorf("expected one warning, got %v", warnings)
+	}
+
+	// The accessors return copies
+	meta["cache"] = "hit"
+	if c.Meta()["cache"] != "miss" {
+		t.Error("expected modifying the returned meta not to affect the context")
+	}
+}
+
+func TestContext_EnvelopeEmpty(t *testing.T) {
+	c := NewContext("Noop")
+	if c.Meta() != nil || c.Warnings() != nil {
+		t.Errorf("expected no meta and no warnings, got %v, %v", c.Meta(), c.Warnings())
+	}
+}
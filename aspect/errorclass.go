@@ -0,0 +1,52 @@
+// Package aspect - errorclass provides advice classifying invocation errors into categories
+package aspect
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrorClassMetadataKey is the metadata key backing Context.ErrorClass.
+const ErrorClassMetadataKey = "aspect.errorClass"
+
+const (
+	ErrorClassNone      ErrorClass = ""          // ErrorClassNone means the error was not classified.
+	ErrorClassRetryable ErrorClass = "retryable" // ErrorClassRetryable is a transient failure worth retrying.
+	ErrorClassClient    ErrorClass = "client"    // ErrorClassClient is a failure caused by the caller, e.g. invalid input.
+	ErrorClassServer    ErrorClass = "server"    // ErrorClassServer is an internal failure not worth retrying.
+	ErrorClassFatal     ErrorClass = "fatal"     // ErrorClassFatal is an unrecoverable failure.
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// ErrorClass is the category of an invocation error. Classes are plain strings, so they can be
+// used as metric labels; classifiers may return classes of their own.
+type ErrorClass string
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// ClassifyErrors returns advice classifying the error of the invocation with classifier and
+// recording the class, readable by downstream advice with Context.ErrorClass. Errors classified
+// as ErrorClassRetryable also mark the invocation retriable (see SetRetriable). Successful
+// invocations are not classified.
+//
+// Install it as After advice with a high priority so metrics and logging advice see the class,
+// or pass it to Retry as a classify handler to decide on retries per attempt.
+func ClassifyErrors(classifier func(error) ErrorClass) AdviceFunc {
+	return func(c *Context) error {
+		if c.Error == nil {
+			return nil
+		}
+
+		class := classifier(c.Error)
+		c.SetMetadataVal(ErrorClassMetadataKey, class)
+		if class == ErrorClassRetryable {
+			c.SetRetriable(true)
+		}
+		return nil
+	}
+}
+
+// ErrorClass returns the class recorded by ClassifyErrors, or ErrorClassNone.
+func (c *Context) ErrorClass() ErrorClass {
+	class, _ := c.GetMetadataVal(ErrorClassMetadataKey)
+	errorClass, _ := class.(ErrorClass)
+	return errorClass
+}
@@ -0,0 +1,89 @@
+// Package aspect - errorclass_test validates classifying invocation errors into categories
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestClassifyErrors(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errCorrupt := errors.New("corrupt state")
+	classifier := func(err error) ErrorClass {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return ErrorClassRetryable
+		case errors.Is(err, errNotFound):
+			return ErrorClassClient
+		case errors.Is(err, errCorrupt):
+			return ErrorClassFatal
+		default:
+			return ErrorClassServer
+		}
+	}
+
+	tests := []struct {
+		name          string
+		err           error
+		expected      ErrorClass
+		expectedRetry bool
+	}{
+		{name: "success", err: nil, expected: ErrorClassNone},
+		{name: "timeout", err: context.DeadlineExceeded, expected: ErrorClassRetryable, expectedRetry: true},
+		{name: "not found", err: errNotFound, expected: ErrorClassClient},
+		{name: "corrupt", err: errCorrupt, expected: ErrorClassFatal},
+		{name: "unknown", err: errors.New("boom"), expected: ErrorClassServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("Fetch")
+			registry.MustAddAdvice("Fetch", Advice{Type: After, Priority: 100, Handler: ClassifyErrors(classifier)})
+
+			var class ErrorClass
+			var retriable bool
+			registry.MustAddAdvice("Fetch", Advice{Type: After, Handler: func(c *Context) error {
+				class, retriable = c.ErrorClass(), c.IsRetriable()
+				return nil
+			}})
+
+			_ = Wrap0E(registry, "Fetch", func() error { return tt.err })()
+
+			if class != tt.expected {
+				t.Errorf("expected class %q, got %q", tt.expected, class)
+			}
+			if retriable != tt.expectedRetry {
+				t.Errorf("expected retriable %v, got %v", tt.expectedRetry, retriable)
+			}
+		})
+	}
+}
+
+func TestClassifyErrors_DrivesRetry(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Fetch")
+
+	classifier := func(err error) ErrorClass { return ErrorClassRetryable }
+	registry.MustAddAdvice("Fetch", Advice{
+		Type:    Around,
+		Handler: Retry(3, func(int) time.Duration { return 0 }, ClassifyErrors(classifier)),
+	})
+
+	attempts := 0
+	err := Wrap0E(registry, "Fetch", func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	})()
+
+	if err != nil || attempts != 3 {
+		t.Errorf("expected success after 3 attempts, got %d attempts and %v", attempts, err)
+	}
+}
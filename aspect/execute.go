@@ -0,0 +1,42 @@
+// Package aspect - execute provides manual invocation of a target through the advice chain
+package aspect
+
+import "context"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Execute runs targetFn through the advice chain of funcKey and returns the execution context.
+// It is the untyped counterpart of the Wrap functions, for callers that cannot use a typed wrapper.
+//
+// The target reports its outcome through the context (SetResult, Error). After the call, read
+// the outcome with the read-only accessors Result, Err and Panicked:
+//
+//	c := registry.Execute(ctx, "GetUser", func(c *aspect.Context) {
+//		user, err := getUser(c.Context(), c.Args[0].(string))
+//		c.SetResult(0, user)
+//		c.Error = err
+//	}, "alice")
+//	if err := c.Err(); err != nil { ... }
+//	user := c.Result(0).(*User)
+func (registry *Registry) Execute(ctx context.Context, funcKey FuncKey, targetFn func(c *Context), args ...any) *Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return executeWithAdviceContext(newWrapSite(registry, funcKey), ctx, targetFn, args...)
+}
+
+// WithUnit runs fn as a unit of work through the advice chain of funcKey: the advice runs once
+// around the whole unit and fn receives the shared context, so sub-steps of a workflow called
+// from fn share its metadata and trace without being wrapped themselves:
+//
+//	err := registry.WithUnit("Checkout", func(c *aspect.Context) {
+//		reserveStock(c)
+//		chargeCard(c) // Reads the trace id set by the unit's Before advice
+//	})
+//
+// It returns the final error of the unit: an error set by fn or advice (see Context.Fail), a
+// recovered panic or an advice error. The unit runs with the background context; see Execute
+// for units bound to a context.Context.
+func (registry *Registry) WithUnit(funcKey FuncKey, fn func(c *Context)) error {
+	return registry.Execute(context.Background(), funcKey, fn).Err()
+}
@@ -0,0 +1,170 @@
+// Package aspect - execute_test validates manual execution and the read-only result API
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_Execute(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Divide")
+
+	var beforeCalled bool
+	registry.MustAddAdvice("Divide", Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			beforeCalled = true
+			return nil
+		},
+	})
+
+	divide := func(c *Context) {
+		a, b := c.Args[0].(int), c.Args[1].(int)
+		if b == 0 {
+			c.Error = errors.New("division by zero")
+			return
+		}
+		c.SetResult(0, a/b)
+	}
+
+	c := registry.Execute(context.Background(), "Divide", divide, 10, 2)
+	if !beforeCalled {
+		t.Error("expected Before advice to run")
+	}
+	if c.Result(0) != 5 {
+		t.Errorf("expected result 5, got %v", c.Result(0))
+	}
+	if c.Result(1) != nil {
+		t.Errorf("expected nil for unset result, got %v", c.Result(1))
+	}
+	if c.Err() != nil {
+		t.Errorf("unexpected error: %v", c.Err())
+	}
+	if _, panicked := c.Panicked(); panicked {
+		t.Error("expected no panic")
+	}
+
+	c = registry.Execute(context.Background(), "Divide", divide, 1, 0)
+	if c.Err() == nil || c.Err().Error() != "division by zero" {
+		t.Errorf("expected division error, got %v", c.Err())
+	}
+
+	c = registry.Execute(nil, "Divide", func(c *Context) { panic("boom") })
+	value, panicked := c.Panicked()
+	if !panicked || value != "boom" {
+		t.Errorf("expected panic 'boom', got %v (panicked=%v)", value, panicked)
+	}
+	if c.Err() == nil {
+		t.Error("expected the recovered panic to surface as an error")
+	}
+}
+
+func TestContext_TargetRan(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		advice   Advice
+		expected bool
+	}{
+		{
+			name:     "normal run",
+			ctx:      context.Background(),
+			advice:   Advice{Type: Before, Handler: func(c *Context) error { return nil }},
+			expected: true,
+		},
+		{
+			name: "around skip",
+			ctx:  context.Background(),
+			advice: Advice{Type: Around, Handler: func(c *Context) error {
+				c.Skipped = true
+				return nil
+			}},
+			expected: false,
+		},
+		{
+			name:     "before error",
+			ctx:      context.Background(),
+			advice:   Advice{Type: Before, Handler: func(c *Context) error { return errors.New("denied") }},
+			expected: false,
+		},
+		{
+			name:     "cancelled context",
+			ctx:      cancelled,
+			advice:   Advice{Type: Before, Handler: func(c *Context) error { return nil }},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("Target")
+			registry.MustAddAdvice("Target", tt.advice)
+
+			var ranInAfter bool
+			registry.MustAddAdvice("Target", Advice{Type: After, Handler: func(c *Context) error {
+				ranInAfter = c.TargetRan()
+				return nil
+			}})
+
+			c := registry.Execute(tt.ctx, "Target", func(c *Context) {})
+			if c.TargetRan() != tt.expected {
+				t.Errorf("expected TargetRan=%v, got %v", tt.expected, c.TargetRan())
+			}
+			if ranInAfter != tt.expected {
+				t.Errorf("expected After advice to observe TargetRan=%v, got %v", tt.expected, ranInAfter)
+			}
+		})
+	}
+}
+
+func TestRegistry_WithUnit(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Checkout")
+
+	var beforeRuns, afterRuns int
+	registry.MustAddAdvice("Checkout", Advice{Type: Before, Handler: func(c *Context) error {
+		beforeRuns++
+		c.SetMetadataVal("traceId", "trace-1")
+		return nil
+	}})
+	registry.MustAddAdvice("Checkout", Advice{Type: After, Handler: func(c *Context) error {
+		afterRuns++
+		return nil
+	}})
+
+	var seen []any
+	step := func(c *Context) {
+		traceID, _ := c.GetMetadataVal("traceId")
+		seen = append(seen, traceID)
+	}
+
+	err := registry.WithUnit("Checkout", func(c *Context) {
+		step(c)
+		step(c)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "trace-1" || seen[1] != "trace-1" {
+		t.Errorf("expected both steps to see the unit's trace id, got %v", seen)
+	}
+	if beforeRuns != 1 || afterRuns != 1 {
+		t.Errorf("expected the advice to run once for the unit, got %d Before and %d After", beforeRuns, afterRuns)
+	}
+
+	// A failing or panicking unit returns its error
+	if err := registry.WithUnit("Checkout", func(c *Context) { c.Fail(nil) }); !errors.Is(err, ErrFailed) {
+		t.Errorf("expected ErrFailed, got %v", err)
+	}
+	if err := registry.WithUnit("Checkout", func(c *Context) { panic("boom") }); err == nil {
+		t.Error("expected the panic as error")
+	}
+}
@@ -0,0 +1,43 @@
+// Package aspect - expose makes the execution context available to the target through context.Context
+package aspect
+
+import "context"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// exposeKey is the context key under which the execution context is exposed.
+type exposeKey struct{}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetExposeContext controls whether context-aware targets can retrieve the execution context
+// with FromContext, e.g. to read a correlation ID computed by Before advice:
+//
+//	func GetUser(ctx context.Context, id int) (*User, error) {
+//		if c, ok := aspect.FromContext(ctx); ok {
+//			requestID, _ := c.GetMetadataVal("request_id")
+//			log.Printf("[%v] loading user %d", requestID, id)
+//		}
+//		...
+//	}
+//
+// It is off by default and costs a context allocation per call when enabled.
+func (registry *Registry) SetExposeContext(expose bool) {
+	registry.exposeContext.Store(expose)
+}
+
+// FromContext returns the execution context exposed in ctx by a registry with
+// SetExposeContext enabled.
+func FromContext(ctx context.Context) (*Context, bool) {
+	c, ok := ctx.Value(exposeKey{}).(*Context)
+	return c, ok
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// expose stores c in its own context.Context when the registry exposes execution contexts.
+func (registry *Registry) expose(c *Context) {
+	if registry.exposeContext.Load() {
+		c.ctx = context.WithValue(c.Context(), exposeKey{}, c)
+	}
+}
@@ -0,0 +1,42 @@
+// Package aspect - expose_test validates exposing the execution context to the target
+package aspect
+
+import (
+	"context"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_SetExposeContext(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		c.SetMetadataVal("request_id", "req-42")
+		return nil
+	}})
+
+	var requestID any
+	var exposed bool
+	getUser := Wrap1ECtx(registry, "GetUser", func(ctx context.Context, id int) error {
+		var c *Context
+		if c, exposed = FromContext(ctx); exposed {
+			requestID, _ = c.GetMetadataVal("request_id")
+		}
+		return nil
+	})
+
+	_ = getUser(context.Background(), 1)
+	if exposed {
+		t.Error("expected the context not to be exposed by default")
+	}
+
+	registry.SetExposeContext(true)
+	_ = getUser(context.Background(), 1)
+	if !exposed {
+		t.Fatal("expected the target to retrieve the execution context")
+	}
+	if requestID != "req-42" {
+		t.Errorf("expected metadata set by Before advice, got %v", requestID)
+	}
+}
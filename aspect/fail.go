@@ -0,0 +1,37 @@
+// Package aspect - fail lets advice mark a successful invocation as a logical failure
+package aspect
+
+import "errors"
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrFailed is the error of an invocation marked failed with Context.Fail without a specific error.
+var ErrFailed = errors.New("invocation marked as failed")
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Fail marks the invocation as failed with err (ErrFailed if nil), e.g. when the target returned
+// a nil error with a result that is a failure nonetheless, such as an HTTP 500 response. The
+// error replaces the target's and is returned to the caller.
+//
+// The supported call site is Around advice after the target ran (see Proceed) or when skipping
+// it: AfterReturning advice then does not run, as for any failed invocation. Before the target
+// runs, Fail has no lasting effect, as the target's own error replaces it; to abort a call from
+// Before advice return an error instead. Called from AfterReturning or After advice, Fail still
+// fails the call, but AfterReturning advice that already ran cannot be undone.
+//
+//	func(c *aspect.Context) error { // Around advice
+//		if err := c.Proceed(); err != nil {
+//			return nil
+//		}
+//		if resp, ok := aspect.ResultAs[*http.Response](c, 0); ok && resp.StatusCode >= 500 {
+//			c.Fail(fmt.Errorf("upstream failed with status %d", resp.StatusCode))
+//		}
+//		return nil
+//	}
+func (c *Context) Fail(err error) {
+	if err == nil {
+		err = ErrFailed
+	}
+	c.Error = err
+}
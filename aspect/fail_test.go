@@ -0,0 +1,70 @@
+// Package aspect - fail_test validates marking successful invocations as failed
+package aspect
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_Fail_FromAroundAfterProceed(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Fetch")
+
+	errUpstream := errors.New("upstream returned 500")
+	registry.MustAddAdvice("Fetch", Advice{Type: Around, Handler: func(c *Context) error {
+		if err := c.Proceed(); err != nil {
+			return nil
+		}
+		if status, _ := ResultAs[int](c, 0); status >= 500 {
+			c.Fail(errUpstream)
+		}
+		return nil
+	}})
+
+	var afterReturningRan, afterThrowingRan bool
+	var afterSaw error
+	registry.MustAddAdvice("Fetch", Advice{Type: AfterReturning, Handler: func(c *Context) error {
+		afterReturningRan = true
+		return nil
+	}})
+	registry.MustAddAdvice("Fetch", Advice{Type: AfterThrowing, Handler: func(c *Context) error {
+		afterThrowingRan = true
+		return nil
+	}})
+	registry.MustAddAdvice("Fetch", Advice{Type: After, Handler: func(c *Context) error {
+		afterSaw = c.Error
+		return nil
+	}})
+
+	fetch := Wrap0RE(registry, "Fetch", func() (int, error) { return 500, nil })
+
+	status, err := fetch()
+	if !errors.Is(err, errUpstream) {
+		t.Errorf("expected the failure to be returned, got %v", err)
+	}
+	if status != 500 {
+		t.Errorf("expected the target's result to be kept, got %d", status)
+	}
+	if afterReturningRan {
+		t.Error("expected AfterReturning advice to be skipped for a failed invocation")
+	}
+	if afterThrowingRan {
+		t.Error("expected AfterThrowing advice not to run without a panic")
+	}
+	if afterSaw != errUpstream {
+		t.Errorf("expected After advice to observe the failure, got %v", afterSaw)
+	}
+}
+
+func TestContext_Fail_NilError(t *testing.T) {
+	c := NewContext("Op")
+	c.Fail(nil)
+	if c.Error != ErrFailed {
+		t.Errorf("expected ErrFailed, got %v", c.Error)
+	}
+	if c.Outcome() != OutcomeError {
+		t.Errorf("expected %v, got %v", OutcomeError, c.Outcome())
+	}
+}
@@ -1,14 +1,30 @@
 // Package aspect. fluent provides a fluent/declarative API for registering advice
 package aspect
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"sync"
+)
 
 // -------------------------------------------- Types --------------------------------------------
 
 // FluentBuilder provides a fluent API for configuring advice for a function.
 type FluentBuilder struct {
-	registry *Registry
-	funcKey  FuncKey
+	registry  *Registry
+	funcKey   FuncKey
+	removable bool            // removable builders record the advice they add so Done can undo it.
+	ids       []AdviceID      // ids are the advice added by a removable builder.
+	sequenced bool            // sequenced builders assign decreasing priorities in declaration order.
+	sequence  int             // sequence is the number of advice sequenced so far.
+	once      bool            // once builders skip advice already installed by an earlier run of the same setup.
+	onceCount int             // onceCount is the number of advice declared since Once.
+	ctx       context.Context // ctx is the base context of calls made through the non-Ctx Build functions (see ForCtx).
+	mu        sync.Mutex
 }
 
 // -------------------------------------------- Public Functions --------------------------------------------
@@ -35,109 +51,220 @@ func ForWithRegistry(registry *Registry, funcName FuncKey) *FluentBuilder {
 	}
 }
 
+// ForCtx creates a fluent builder like For carrying ctx as base context, e.g. for request-scoped
+// setups: calls made through functions wrapped with the non-Ctx Build functions (BuildWrap1RE, ...)
+// run with ctx instead of context.Background(), so advice sees its values and cancellation.
+//
+//	getUser := aspect.BuildWrap1RE(aspect.ForCtx(ctx, "GetUser").WithBefore(logRequest), getUserImpl)
+func ForCtx(ctx context.Context, funcName FuncKey) *FluentBuilder {
+	return ForCtxWithRegistry(ctx, DefaultRegistry(), funcName)
+}
+
+// ForCtxWithRegistry creates a fluent builder carrying a base context using a specific registry.
+func ForCtxWithRegistry(ctx context.Context, registry *Registry, funcName FuncKey) *FluentBuilder {
+	return &FluentBuilder{
+		registry: registry,
+		funcKey:  funcName,
+		ctx:      ctx,
+	}
+}
+
+// ForRemovable creates a fluent builder like For whose advice can be removed again:
+//
+//	undo := aspect.ForRemovable("GetUser").WithBefore(logCall).Done()
+//	defer undo()
+func ForRemovable(funcName FuncKey) *FluentBuilder {
+	return ForRemovableWithRegistry(DefaultRegistry(), funcName)
+}
+
+// ForRemovableWithRegistry creates a removable fluent builder using a specific registry.
+func ForRemovableWithRegistry(registry *Registry, funcName FuncKey) *FluentBuilder {
+	return &FluentBuilder{
+		registry:  registry,
+		funcKey:   funcName,
+		removable: true,
+	}
+}
+
+// Done returns a function removing all advice added through a removable builder (see ForRemovable).
+// The returned function may be called several times; for other builders it does nothing.
+func (fb *FluentBuilder) Done() func() {
+	fb.mu.Lock()
+	ids := append([]AdviceID(nil), fb.ids...)
+	fb.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, id := range ids {
+				fb.registry.RemoveAdvice(fb.funcKey, id)
+			}
+		})
+	}
+}
+
+// Sequenced makes advice added afterwards without an explicit priority (WithBefore, WithAfter, ...)
+// run in declaration order: each gets a priority one lower than the previous, starting at zero.
+// Advice with an explicit priority (WithBeforeP, ...) is unaffected; positive priorities still run
+// before sequenced advice.
+//
+//	aspect.For("Checkout").Sequenced().
+//		WithBefore(authenticate). // Priority 0
+//		WithBefore(validate).     // Priority -1
+//		WithBefore(logCall)       // Priority -2
+func (fb *FluentBuilder) Sequenced() *FluentBuilder {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	fb.sequenced = true
+	return fb
+}
+
+// Once marks the advice added by subsequent With* calls as install-once, so re-running a setup
+// function does not install it twice, e.g. to make bootstrap code idempotent:
+//
+//	func setup() {
+//		aspect.For("GetUser").Once().
+//			WithBefore(logCall).
+//			WithAround(cache)
+//	}
+//
+// Install-once advice is identified by name: unnamed advice is named after the advice type, the
+// handler's function name and its position after Once, so the same declarations map to the same
+// names on every run. Advice already installed under that name is not added again.
+// Running the same setup concurrently is not guarded.
+func (fb *FluentBuilder) Once() *FluentBuilder {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	fb.once = true
+	return fb
+}
+
 // WithBefore adds a Before advice to the function.
 func (fb *FluentBuilder) WithBefore(handler AdviceFunc) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.addSequenced(Advice{
 		Type:    Before,
 		Handler: handler,
 	})
-	return fb
+}
+
+// WithBeforeWhen adds a Before advice to the function only if predicate holds, e.g. a
+// configuration flag. The predicate is evaluated once, at setup: unlike Advice.Condition, which is
+// checked on every call, advice that is not installed costs nothing at runtime.
+func (fb *FluentBuilder) WithBeforeWhen(predicate func() bool, handler AdviceFunc) *FluentBuilder {
+	if !predicate() {
+		return fb
+	}
+	return fb.WithBefore(handler)
+}
+
+// WithBeforeWhenEnv adds a Before advice to the function only if the environment variable name
+// is enabled at setup, e.g. for debug-only advice (see WithBeforeWhen). A variable is enabled if
+// it is set to a true boolean value ("1", "true", ...) or to any other non-boolean, non-empty
+// value; unset, empty and false values ("0", "false", ...) leave the advice out.
+func (fb *FluentBuilder) WithBeforeWhenEnv(name string, handler AdviceFunc) *FluentBuilder {
+	return fb.WithBeforeWhen(func() bool { return envEnabled(name) }, handler)
 }
 
 // WithBeforeP adds a Before advice with a specific priority to the function.
 func (fb *FluentBuilder) WithBeforeP(handler AdviceFunc, priority int) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.add(Advice{
 		Type:     Before,
 		Handler:  handler,
 		Priority: priority,
 	})
-	return fb
 }
 
 // WithAfter adds an After advice to the function.
 func (fb *FluentBuilder) WithAfter(handler AdviceFunc) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.addSequenced(Advice{
 		Type:    After,
 		Handler: handler,
 	})
-	return fb
 }
 
 // WithAfterP adds an After advice with a specific priority to the function.
 func (fb *FluentBuilder) WithAfterP(handler AdviceFunc, priority int) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.add(Advice{
 		Type:     After,
 		Handler:  handler,
 		Priority: priority,
 	})
-	return fb
 }
 
 // WithAround adds an Around advice to the function.
 func (fb *FluentBuilder) WithAround(handler AdviceFunc) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.addSequenced(Advice{
 		Type:    Around,
 		Handler: handler,
 	})
-	return fb
 }
 
 // WithAroundP adds an Around advice with a specific priority to the function.
 func (fb *FluentBuilder) WithAroundP(handler AdviceFunc, priority int) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.add(Advice{
 		Type:     Around,
 		Handler:  handler,
 		Priority: priority,
 	})
-	return fb
 }
 
 // WithAfterReturning adds an AfterReturning advice to the function.
 func (fb *FluentBuilder) WithAfterReturning(handler AdviceFunc) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.addSequenced(Advice{
 		Type:    AfterReturning,
 		Handler: handler,
 	})
-	return fb
 }
 
 // WithAfterReturningP adds an AfterReturning advice with a specific priority to the function.
 func (fb *FluentBuilder) WithAfterReturningP(handler AdviceFunc, priority int) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.add(Advice{
 		Type:     AfterReturning,
 		Handler:  handler,
 		Priority: priority,
 	})
-	return fb
 }
 
 // WithAfterThrowing adds an AfterThrowing advice to the function.
 func (fb *FluentBuilder) WithAfterThrowing(handler AdviceFunc) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.addSequenced(Advice{
 		Type:    AfterThrowing,
 		Handler: handler,
 	})
-	return fb
 }
 
 // WithAfterThrowingP adds an AfterThrowing advice with a specific priority to the function.
 func (fb *FluentBuilder) WithAfterThrowingP(handler AdviceFunc, priority int) *FluentBuilder {
-	fb.registry.RegisterOrGet(fb.funcKey)
-	fb.registry.MustAddAdvice(fb.funcKey, Advice{
+	return fb.add(Advice{
 		Type:     AfterThrowing,
 		Handler:  handler,
 		Priority: priority,
 	})
-	return fb
+}
+
+// WithRecover adds the common "log and recover" AfterThrowing advice to the function: panics of
+// the target are logged at error level with the function name, the panic value and the stack of
+// the panicking goroutine. The engine already recovers panics and returns them as errors, so the
+// advice only needs to log. A nil logger uses slog.Default.
+func (fb *FluentBuilder) WithRecover(logger *slog.Logger) *FluentBuilder {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return fb.addSequenced(Advice{
+		Type: AfterThrowing,
+		Name: "recover",
+		Handler: func(c *Context) error {
+			// AfterThrowing runs before the panicking frames unwind, so the stack shows the panic site
+			logger.ErrorContext(c.Context(), "panic recovered",
+				"func", string(c.FunctionName),
+				"panic", c.PanicValue,
+				"stack", string(debug.Stack()),
+			)
+			return nil
+		},
+	})
 }
 
 // GetRegistry returns the registry used by this fluent builder.
@@ -151,6 +278,65 @@ func (fb *FluentBuilder) GetFuncKey() FuncKey {
 	return fb.funcKey
 }
 
+// envEnabled reports whether the environment variable name is enabled (see WithBeforeWhenEnv).
+func envEnabled(name string) bool {
+	val := os.Getenv(name)
+	if enabled, err := strconv.ParseBool(val); err == nil {
+		return enabled
+	}
+	return val != ""
+}
+
+// addSequenced adds advice declared without a priority, assigning the next priority of the sequence
+// if the builder is sequenced.
+func (fb *FluentBuilder) addSequenced(advice Advice) *FluentBuilder {
+	fb.mu.Lock()
+	if fb.sequenced {
+		advice.Priority = -fb.sequence
+		fb.sequence++
+	}
+	fb.mu.Unlock()
+	return fb.add(advice)
+}
+
+// nameOnce names unnamed advice of an install-once builder after its declaration (see Once) and
+// reports whether the builder is install-once.
+func (fb *FluentBuilder) nameOnce(advice *Advice) bool {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	if !fb.once {
+		return false
+	}
+	if advice.Name == "" {
+		advice.Name = fmt.Sprintf("once:%s:%s#%d", adviceTypeNames[advice.Type], handlerName(advice.Handler), fb.onceCount)
+	}
+	fb.onceCount++
+	return true
+}
+
+// add registers the function if needed and adds advice to it, recording its ID for removable builders.
+func (fb *FluentBuilder) add(advice Advice) *FluentBuilder {
+	chain := fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.nameOnce(&advice) && chain.hasNamed(advice.Type, advice.Name) {
+		return fb
+	}
+
+	if !fb.removable {
+		fb.registry.MustAddAdvice(fb.funcKey, advice)
+		return fb
+	}
+
+	id, err := fb.registry.AddRemovableAdvice(fb.funcKey, advice)
+	if err != nil {
+		panic(err)
+	}
+	fb.mu.Lock()
+	fb.ids = append(fb.ids, id)
+	fb.mu.Unlock()
+	return fb
+}
+
 // The Wrap method is intentionally omitted to avoid reflection usage.
 // Users should use the specific typed Wrap methods like Wrap0, Wrap1, etc.
 // based on their function signatures for type safety.
@@ -170,11 +356,343 @@ func (fb *FluentBuilder) Wrap0ECtx(fn func(context.Context) error) func(context.
 }
 
 // The generic context-aware methods are removed since Go doesn't support generic methods.
-// Users should call the registry's context-aware Wrap functions directly after configuring advice.
+// Use the package-level Build functions below instead.
 // Example:
-// aspect.For("MyFunction").
-//     WithBefore(myBeforeAdvice).
-//     WithAfter(myAfterAdvice)
+// wrappedFn := aspect.BuildWrap1Ctx(
+//     aspect.For("MyFunction").
+//         WithBefore(myBeforeAdvice).
+//         WithAfter(myAfterAdvice),
+//     myFunction,
+// )
+
+// -- Fluent Build --
+
+// The Build functions declare and wrap a context-aware function in one fluent expression.
+// They are package-level functions because Go methods cannot have type parameters.
+// Each registers the builder's function if absent, so advice can be added before or after:
 //
-// builder := aspect.For("MyFunction")
-// wrappedFn := aspect.Wrap1Ctx(builder.GetRegistry(), builder.GetFuncKey(), myFunction)
+//	GetUser := aspect.BuildWrap1RECtx(aspect.For("GetUser").WithBefore(logRequest), getUserImpl)
+
+// BuildWrap0Ctx wraps a context-aware function with no arguments and no return values using the builder's function key.
+func BuildWrap0Ctx(fb *FluentBuilder, fn func(context.Context)) func(context.Context) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap0Ctx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap0RCtx wraps a context-aware function with no arguments and one return value using the builder's function key.
+func BuildWrap0RCtx[R any](fb *FluentBuilder, fn func(context.Context) R) func(context.Context) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap0RCtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap0ECtx wraps a context-aware function with no arguments that returns error using the builder's function key.
+func BuildWrap0ECtx(fb *FluentBuilder, fn func(context.Context) error) func(context.Context) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap0ECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap0RECtx wraps a context-aware function with no arguments that returns (result, error) using the builder's function key.
+func BuildWrap0RECtx[R any](fb *FluentBuilder, fn func(context.Context) (R, error)) func(context.Context) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap0RECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap1Ctx wraps a context-aware function with one argument and no return values using the builder's function key.
+func BuildWrap1Ctx[A any](fb *FluentBuilder, fn func(context.Context, A)) func(context.Context, A) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap1Ctx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap1RCtx wraps a context-aware function with one argument and one return value using the builder's function key.
+func BuildWrap1RCtx[A, R any](fb *FluentBuilder, fn func(context.Context, A) R) func(context.Context, A) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap1RCtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap1ECtx wraps a context-aware function with one argument that returns error using the builder's function key.
+func BuildWrap1ECtx[A any](fb *FluentBuilder, fn func(context.Context, A) error) func(context.Context, A) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap1ECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap1RECtx wraps a context-aware function with one argument that returns (result, error) using the builder's function key.
+func BuildWrap1RECtx[A, R any](fb *FluentBuilder, fn func(context.Context, A) (R, error)) func(context.Context, A) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap1RECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap2Ctx wraps a context-aware function with two arguments and no return values using the builder's function key.
+func BuildWrap2Ctx[A, B any](fb *FluentBuilder, fn func(context.Context, A, B)) func(context.Context, A, B) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap2Ctx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap2RCtx wraps a context-aware function with two arguments and one return value using the builder's function key.
+func BuildWrap2RCtx[A, B, R any](fb *FluentBuilder, fn func(context.Context, A, B) R) func(context.Context, A, B) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap2RCtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap2ECtx wraps a context-aware function with two arguments that returns error using the builder's function key.
+func BuildWrap2ECtx[A, B any](fb *FluentBuilder, fn func(context.Context, A, B) error) func(context.Context, A, B) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap2ECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap2RECtx wraps a context-aware function with two arguments that returns (result, error) using the builder's function key.
+func BuildWrap2RECtx[A, B, R any](fb *FluentBuilder, fn func(context.Context, A, B) (R, error)) func(context.Context, A, B) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap2RECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap3Ctx wraps a context-aware function with three arguments and no return values using the builder's function key.
+func BuildWrap3Ctx[A, B, C any](fb *FluentBuilder, fn func(context.Context, A, B, C)) func(context.Context, A, B, C) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap3Ctx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap3RCtx wraps a context-aware function with three arguments and one return value using the builder's function key.
+func BuildWrap3RCtx[A, B, C, R any](fb *FluentBuilder, fn func(context.Context, A, B, C) R) func(context.Context, A, B, C) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap3RCtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap3ECtx wraps a context-aware function with three arguments that returns error using the builder's function key.
+func BuildWrap3ECtx[A, B, C any](fb *FluentBuilder, fn func(context.Context, A, B, C) error) func(context.Context, A, B, C) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap3ECtx(fb.registry, fb.funcKey, fn)
+}
+
+// BuildWrap3RECtx wraps a context-aware function with three arguments that returns (result, error) using the builder's function key.
+func BuildWrap3RECtx[A, B, C, R any](fb *FluentBuilder, fn func(context.Context, A, B, C) (R, error)) func(context.Context, A, B, C) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	return Wrap3RECtx(fb.registry, fb.funcKey, fn)
+}
+
+// The non-Ctx Build functions wrap plain functions the same way. Calls made through them run with
+// the builder's base context (see ForCtx), with the registry's default timeout applied as for
+// context-aware calls; without a base context they behave like the corresponding Wrap functions.
+
+// BuildWrap0 wraps a function with no arguments and no return values using the builder's function key and base context.
+func BuildWrap0(fb *FluentBuilder, fn func()) func() {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap0(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap0Ctx(fb.registry, fb.funcKey, func(_ context.Context) {
+		fn()
+	})
+	return func() {
+		wrapped(fb.ctx)
+	}
+}
+
+// BuildWrap0R wraps a function with no arguments and one return value using the builder's function key and base context.
+func BuildWrap0R[R any](fb *FluentBuilder, fn func() R) func() R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap0R(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap0RCtx(fb.registry, fb.funcKey, func(_ context.Context) R {
+		return fn()
+	})
+	return func() R {
+		return wrapped(fb.ctx)
+	}
+}
+
+// BuildWrap0E wraps a function with no arguments that returns error using the builder's function key and base context.
+func BuildWrap0E(fb *FluentBuilder, fn func() error) func() error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap0E(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap0ECtx(fb.registry, fb.funcKey, func(_ context.Context) error {
+		return fn()
+	})
+	return func() error {
+		return wrapped(fb.ctx)
+	}
+}
+
+// BuildWrap0RE wraps a function with no arguments that returns (result, error) using the builder's function key and base context.
+func BuildWrap0RE[R any](fb *FluentBuilder, fn func() (R, error)) func() (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap0RE(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap0RECtx(fb.registry, fb.funcKey, func(_ context.Context) (R, error) {
+		return fn()
+	})
+	return func() (R, error) {
+		return wrapped(fb.ctx)
+	}
+}
+
+// BuildWrap1 wraps a function with one argument and no return values using the builder's function key and base context.
+func BuildWrap1[A any](fb *FluentBuilder, fn func(A)) func(A) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap1(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap1Ctx(fb.registry, fb.funcKey, func(_ context.Context, a A) {
+		fn(a)
+	})
+	return func(a A) {
+		wrapped(fb.ctx, a)
+	}
+}
+
+// BuildWrap1R wraps a function with one argument and one return value using the builder's function key and base context.
+func BuildWrap1R[A, R any](fb *FluentBuilder, fn func(A) R) func(A) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap1R(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap1RCtx(fb.registry, fb.funcKey, func(_ context.Context, a A) R {
+		return fn(a)
+	})
+	return func(a A) R {
+		return wrapped(fb.ctx, a)
+	}
+}
+
+// BuildWrap1E wraps a function with one argument that returns error using the builder's function key and base context.
+func BuildWrap1E[A any](fb *FluentBuilder, fn func(A) error) func(A) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap1E(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap1ECtx(fb.registry, fb.funcKey, func(_ context.Context, a A) error {
+		return fn(a)
+	})
+	return func(a A) error {
+		return wrapped(fb.ctx, a)
+	}
+}
+
+// BuildWrap1RE wraps a function with one argument that returns (result, error) using the builder's function key and base context.
+func BuildWrap1RE[A, R any](fb *FluentBuilder, fn func(A) (R, error)) func(A) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap1RE(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap1RECtx(fb.registry, fb.funcKey, func(_ context.Context, a A) (R, error) {
+		return fn(a)
+	})
+	return func(a A) (R, error) {
+		return wrapped(fb.ctx, a)
+	}
+}
+
+// BuildWrap2 wraps a function with two arguments and no return values using the builder's function key and base context.
+func BuildWrap2[A, B any](fb *FluentBuilder, fn func(A, B)) func(A, B) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap2(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap2Ctx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B) {
+		fn(a, b)
+	})
+	return func(a A, b B) {
+		wrapped(fb.ctx, a, b)
+	}
+}
+
+// BuildWrap2R wraps a function with two arguments and one return value using the builder's function key and base context.
+func BuildWrap2R[A, B, R any](fb *FluentBuilder, fn func(A, B) R) func(A, B) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap2R(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap2RCtx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B) R {
+		return fn(a, b)
+	})
+	return func(a A, b B) R {
+		return wrapped(fb.ctx, a, b)
+	}
+}
+
+// BuildWrap2E wraps a function with two arguments that returns error using the builder's function key and base context.
+func BuildWrap2E[A, B any](fb *FluentBuilder, fn func(A, B) error) func(A, B) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap2E(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap2ECtx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B) error {
+		return fn(a, b)
+	})
+	return func(a A, b B) error {
+		return wrapped(fb.ctx, a, b)
+	}
+}
+
+// BuildWrap2RE wraps a function with two arguments that returns (result, error) using the builder's function key and base context.
+func BuildWrap2RE[A, B, R any](fb *FluentBuilder, fn func(A, B) (R, error)) func(A, B) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap2RE(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap2RECtx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B) (R, error) {
+		return fn(a, b)
+	})
+	return func(a A, b B) (R, error) {
+		return wrapped(fb.ctx, a, b)
+	}
+}
+
+// BuildWrap3 wraps a function with three arguments and no return values using the builder's function key and base context.
+func BuildWrap3[A, B, C any](fb *FluentBuilder, fn func(A, B, C)) func(A, B, C) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap3(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap3Ctx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B, c C) {
+		fn(a, b, c)
+	})
+	return func(a A, b B, c C) {
+		wrapped(fb.ctx, a, b, c)
+	}
+}
+
+// BuildWrap3R wraps a function with three arguments and one return value using the builder's function key and base context.
+func BuildWrap3R[A, B, C, R any](fb *FluentBuilder, fn func(A, B, C) R) func(A, B, C) R {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap3R(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap3RCtx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B, c C) R {
+		return fn(a, b, c)
+	})
+	return func(a A, b B, c C) R {
+		return wrapped(fb.ctx, a, b, c)
+	}
+}
+
+// BuildWrap3E wraps a function with three arguments that returns error using the builder's function key and base context.
+func BuildWrap3E[A, B, C any](fb *FluentBuilder, fn func(A, B, C) error) func(A, B, C) error {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap3E(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap3ECtx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B, c C) error {
+		return fn(a, b, c)
+	})
+	return func(a A, b B, c C) error {
+		return wrapped(fb.ctx, a, b, c)
+	}
+}
+
+// BuildWrap3RE wraps a function with three arguments that returns (result, error) using the builder's function key and base context.
+func BuildWrap3RE[A, B, C, R any](fb *FluentBuilder, fn func(A, B, C) (R, error)) func(A, B, C) (R, error) {
+	fb.registry.RegisterOrGet(fb.funcKey)
+	if fb.ctx == nil {
+		return Wrap3RE(fb.registry, fb.funcKey, fn)
+	}
+	wrapped := Wrap3RECtx(fb.registry, fb.funcKey, func(_ context.Context, a A, b B, c C) (R, error) {
+		return fn(a, b, c)
+	})
+	return func(a A, b B, c C) (R, error) {
+		return wrapped(fb.ctx, a, b, c)
+	}
+}
@@ -2,7 +2,11 @@
 package aspect
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -379,3 +383,250 @@ func TestFluentAPI_WithError(t *testing.T) {
 		t.Errorf("expected error 'test error', got %v", err)
 	}
 }
+
+// TestFluentAPI_BuildWrapCtx tests declaring and wrapping a context-aware function fluently
+func TestFluentAPI_BuildWrapCtx(t *testing.T) {
+	registry := NewRegistry()
+
+	type ctxKey string
+	var seenRequestID any
+
+	getUser := BuildWrap1RECtx(
+		ForWithRegistry(registry, "GetUserWithContext").
+			WithBefore(func(c *Context) error {
+				seenRequestID = c.Context().Value(ctxKey("request_id"))
+				return nil
+			}),
+		func(ctx context.Context, id string) (string, error) {
+			return "user-" + id, nil
+		},
+	)
+
+	ctx := context.WithValue(context.Background(), ctxKey("request_id"), "req-1")
+	user, err := getUser(ctx, "42")
+	if err != nil || user != "user-42" {
+		t.Fatalf("unexpected result: %q, %v", user, err)
+	}
+	if seenRequestID != "req-1" {
+		t.Errorf("expected advice to see request id, got %v", seenRequestID)
+	}
+
+	// Building registers the function when no advice has been added yet
+	count := BuildWrap0RCtx(ForWithRegistry(registry, "Count"), func(ctx context.Context) int { return 3 })
+	if !registry.IsRegistered("Count") {
+		t.Error("expected Build to register the function")
+	}
+	if got := count(context.Background()); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+}
+
+// TestFluentAPI_ForRemovable tests undoing advice installed through a removable builder
+func TestFluentAPI_ForRemovable(t *testing.T) {
+	registry := NewRegistry()
+
+	var permanent, scoped int
+	ForWithRegistry(registry, "Scoped").WithBefore(func(c *Context) error {
+		permanent++
+		return nil
+	})
+	undo := ForRemovableWithRegistry(registry, "Scoped").
+		WithBefore(func(c *Context) error {
+			scoped++
+			return nil
+		}).
+		WithAfterP(func(c *Context) error {
+			scoped++
+			return nil
+		}, 10).
+		Done()
+
+	wrapped := Wrap0(registry, "Scoped", func() {})
+
+	wrapped()
+	if permanent != 1 || scoped != 2 {
+		t.Fatalf("expected all advice to fire, got permanent=%d scoped=%d", permanent, scoped)
+	}
+
+	undo()
+	undo() // Idempotent
+
+	wrapped()
+	if permanent != 2 || scoped != 2 {
+		t.Errorf("expected only permanent advice to fire after undo, got permanent=%d scoped=%d", permanent, scoped)
+	}
+	if registry.GetAdviceCount("Scoped") != 1 {
+		t.Errorf("expected 1 remaining advice, got %d", registry.GetAdviceCount("Scoped"))
+	}
+}
+
+func TestFluentAPI_WithRecover(t *testing.T) {
+	registry := NewRegistry()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	builder := ForWithRegistry(registry, "Explode").WithRecover(logger)
+
+	err := Wrap0E(builder.GetRegistry(), builder.GetFuncKey(), func() error {
+		panic("kaboom")
+	})()
+
+	if err == nil {
+		t.Fatal("expected the panic to surface as an error")
+	}
+
+	logged := buf.String()
+	for _, expected := range []string{"panic recovered", "func=Explode", "panic=kaboom", "TestFluentAPI_WithRecover"} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("expected log to contain %q, got %s", expected, logged)
+		}
+	}
+}
+
+func TestFluentAPI_Sequenced(t *testing.T) {
+	registry := NewRegistry()
+
+	var order []string
+	record := func(name string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	ForWithRegistry(registry, "Checkout").Sequenced().
+		WithBefore(record("auth")).
+		WithBefore(record("validate")).
+		WithBeforeP(record("explicit"), 10).
+		WithBefore(record("log")).
+		WithAfter(record("after"))
+
+	Wrap0(registry, "Checkout", func() {})()
+
+	expected := "explicit,auth,validate,log,after"
+	if got := strings.Join(order, ","); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	var priorities []int
+	for _, step := range registry.ExecutionPlan("Checkout") {
+		if step.Phase == "Before" {
+			priorities = append(priorities, step.Priority)
+		}
+	}
+	if len(priorities) != 4 || priorities[0] != 10 || priorities[1] != 0 || priorities[2] != -1 || priorities[3] != -2 {
+		t.Errorf("expected priorities [10 0 -1 -2], got %v", priorities)
+	}
+
+	// Builders are not sequenced by default
+	ForWithRegistry(registry, "Plain").WithBefore(record("a")).WithBefore(record("b"))
+	for _, step := range registry.ExecutionPlan("Plain") {
+		if step.Phase == "Before" && step.Priority != 0 {
+			t.Errorf("expected priority 0 without Sequenced, got %d", step.Priority)
+		}
+	}
+}
+
+func TestFluentAPI_Once(t *testing.T) {
+	registry := NewRegistry()
+
+	var calls int
+	setup := func() {
+		ForWithRegistry(registry, "Bootstrap").Once().
+			WithBefore(func(c *Context) error {
+				calls++
+				return nil
+			}).
+			WithAfterP(func(c *Context) error { return nil }, 5).
+			WithRecover(nil)
+	}
+	setup()
+	setup()
+
+	if count := registry.GetAdviceCount("Bootstrap"); count != 3 {
+		t.Errorf("expected the advice to be installed once (3 advice), got %d", count)
+	}
+	Wrap0(registry, "Bootstrap", func() {})()
+	if calls != 1 {
+		t.Errorf("expected the Before advice to run once, ran %d times", calls)
+	}
+
+	// Without Once, re-running a setup duplicates the advice
+	for i := 0; i < 2; i++ {
+		ForWithRegistry(registry, "Plain").WithBefore(func(c *Context) error { return nil })
+	}
+	if count := registry.GetAdviceCount("Plain"); count != 2 {
+		t.Errorf("expected duplicated advice without Once, got %d", count)
+	}
+}
+
+func TestFluentAPI_WithBeforeWhenEnv(t *testing.T) {
+	registry := NewRegistry()
+	noop := func(c *Context) error { return nil }
+
+	t.Setenv("ASPECT_TEST_DEBUG", "")
+	ForWithRegistry(registry, "Off").WithBeforeWhenEnv("ASPECT_TEST_DEBUG", noop)
+	if count := registry.GetAdviceCount("Off"); count != 0 {
+		t.Errorf("expected no advice with the variable empty, got %d", count)
+	}
+
+	t.Setenv("ASPECT_TEST_DEBUG", "false")
+	ForWithRegistry(registry, "False").WithBeforeWhenEnv("ASPECT_TEST_DEBUG", noop)
+	if count := registry.GetAdviceCount("False"); count != 0 {
+		t.Errorf("expected no advice with the variable false, got %d", count)
+	}
+
+	t.Setenv("ASPECT_TEST_DEBUG", "1")
+	ForWithRegistry(registry, "On").WithBeforeWhenEnv("ASPECT_TEST_DEBUG", noop)
+	if count := registry.GetAdviceCount("On"); count != 1 {
+		t.Errorf("expected the advice with the variable enabled, got %d", count)
+	}
+
+	// The predicate is evaluated at setup, not per call
+	var evaluations int
+	ForWithRegistry(registry, "Config").WithBeforeWhen(func() bool { evaluations++; return true }, noop)
+	wrapped := Wrap0(registry, "Config", func() {})
+	wrapped()
+	wrapped()
+	if evaluations != 1 {
+		t.Errorf("expected the predicate to be evaluated once, got %d", evaluations)
+	}
+}
+
+func TestFluentAPI_ForCtx(t *testing.T) {
+	registry := NewRegistry()
+
+	type ctxKey string
+	base := context.WithValue(context.Background(), ctxKey("request_id"), "req-1")
+
+	var seenRequestID any
+	getUser := BuildWrap1RE(
+		ForCtxWithRegistry(base, registry, "GetUser").
+			WithBefore(func(c *Context) error {
+				seenRequestID = c.Context().Value(ctxKey("request_id"))
+				return nil
+			}),
+		func(id string) (string, error) {
+			return "user-" + id, nil
+		},
+	)
+
+	user, err := getUser("42")
+	if err != nil || user != "user-42" {
+		t.Fatalf("unexpected result: %q, %v", user, err)
+	}
+	if seenRequestID != "req-1" {
+		t.Errorf("expected advice to see the base context's request id, got %v", seenRequestID)
+	}
+
+	// Without a base context the call runs with the background context
+	var seenCtx context.Context
+	ping := BuildWrap0(ForWithRegistry(registry, "Ping").WithBefore(func(c *Context) error {
+		seenCtx = c.Context()
+		return nil
+	}), func() {})
+	ping()
+	if seenCtx != context.Background() {
+		t.Errorf("expected the background context, got %v", seenCtx)
+	}
+}
@@ -0,0 +1,93 @@
+// Package aspect - freeze makes advice chains immutable for read-only production registries
+package aspect
+
+import (
+	"errors"
+	"slices"
+	"sort"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrChainFrozen is returned when adding advice to a frozen chain (see AdviceChain.Freeze).
+var ErrChainFrozen = errors.New("advice chain is frozen")
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Freeze makes the chain immutable once it is fully built, e.g. at the end of setup, so an
+// accidental change at runtime is rejected: Add, Merge, Clear, ClearType and the Registry's
+// RemoveAdvice, RemoveAdviceWhere and RemoveTemporary return ErrChainFrozen. The advice is sorted by
+// priority once, so invocations read the lists without locking, copying or sorting them, unless
+// priority overrides or global and pattern advice require merging. Freezing cannot be undone;
+// use Registry.SetChain to swap in a new chain. Freezing a frozen chain does nothing.
+func (ac *AdviceChain) Freeze() {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if ac.frozen.Load() {
+		return
+	}
+	for _, list := range []*[]Advice{&ac.before, &ac.after, &ac.around, &ac.afterReturning, &ac.afterThrowing} {
+		sort.SliceStable(*list, func(i, j int) bool {
+			return (*list)[i].Priority > (*list)[j].Priority
+		})
+		*list = slices.Clip(*list) // Appending to a shared list must copy it
+	}
+	ac.frozen.Store(true)
+}
+
+// Frozen reports whether the chain is frozen.
+func (ac *AdviceChain) Frozen() bool {
+	return ac.frozen.Load()
+}
+
+// FreezeAll freezes the chains of all registered functions and the global and pattern advice of
+// the registry (see AdviceChain.Freeze), turning it read-only once setup completed: AddAdvice,
+// AddGlobalAdvice, AddPatternAdvice, ClearAdvice, ClearAdviceType and the Remove functions
+// return ErrChainFrozen, also for patterns without advice so far. Functions registered afterwards
+// start with a frozen empty chain. SetChain still swaps in a new chain, and Clear resets the
+// registry to a mutable state. Wrappers memoize their lookups, so a call through a frozen
+// registry takes no registry or chain lock unless a registry setting changed since the last call.
+func (registry *Registry) FreezeAll() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.frozen.Store(true)
+	for _, chain := range registry.entries {
+		chain.Freeze()
+	}
+	registry.global.Freeze()
+	for _, pattern := range registry.patterns {
+		pattern.chain.Freeze()
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// list returns the advice list of the given type. The caller must hold the lock or the chain
+// must be frozen.
+func (ac *AdviceChain) list(adviceType AdviceType) []Advice {
+	switch adviceType {
+	case Before:
+		return ac.before
+	case After:
+		return ac.after
+	case Around:
+		return ac.around
+	case AfterReturning:
+		return ac.afterReturning
+	case AfterThrowing:
+		return ac.afterThrowing
+	}
+	return nil
+}
+
+// sortedByPriority reports whether the advice is ordered by descending priority.
+func sortedByPriority(adviceList []Advice) bool {
+	for i := 1; i < len(adviceList); i++ {
+		if adviceList[i].Priority > adviceList[i-1].Priority {
+			return false
+		}
+	}
+	return true
+}
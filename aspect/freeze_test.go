@@ -0,0 +1,189 @@
+// Package aspect - freeze_test validates frozen advice chains
+package aspect
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// appendName returns a handler appending name to order.
+func appendName(order *[]string, name string) AdviceFunc {
+	return func(c *Context) error {
+		*order = append(*order, name)
+		return nil
+	}
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAdviceChain_Freeze(t *testing.T) {
+	var order []string
+	chain := NewAdviceChain()
+	chain.Add(Advice{Type: Before, Priority: 1, Handler: appendName(&order, "low")})
+	chain.Add(Advice{Type: Before, Priority: 3, Handler: appendName(&order, "high")})
+	chain.Add(Advice{Type: Before, Priority: 2, Handler: appendName(&order, "mid")})
+	chain.Freeze()
+
+	if !chain.Frozen() {
+		t.Fatal("expected the chain to be frozen")
+	}
+	if err := chain.ExecuteBefore(NewContext("Fn")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(order) != 3 || order[0] != "high" || order[1] != "mid" || order[2] != "low" {
+		t.Errorf("expected priority order, got %v", order)
+	}
+
+	other := NewAdviceChain()
+	other.Add(Advice{Type: After, Handler: appendName(&order, "merged")})
+	mutators := map[string]func() error{
+		"Add":       func() error { return chain.Add(Advice{Type: After, Handler: appendName(&order, "late")}) },
+		"Merge":     func() error { return chain.Merge(other) },
+		"MergeSelf": func() error { return chain.Merge(chain) },
+		"Clear":     chain.Clear,
+		"ClearType": func() error { return chain.ClearType(Before) },
+	}
+	for name, mutate := range mutators {
+		if err := mutate(); !errors.Is(err, ErrChainFrozen) {
+			t.Errorf("expected %s to fail with ErrChainFrozen, got %v", name, err)
+		}
+	}
+	if chain.Count() != 3 {
+		t.Errorf("expected the chain to be unchanged, got %d advice", chain.Count())
+	}
+}
+
+func TestRegistry_FreezeAll(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var order []string
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: appendName(&order, "log")})
+	id, _ := registry.AddRemovableAdvice("GetUser", Advice{Type: Before, Priority: 10, Handler: appendName(&order, "auth")})
+	registry.MustAddPatternAdvice("Get*", Advice{Type: Before, Handler: appendName(&order, "trace")})
+	registry.FreezeAll()
+
+	noop := func(c *Context) error { return nil }
+	mutators := map[string]func() error{
+		"AddAdvice":        func() error { return registry.AddAdvice("GetUser", Advice{Type: Before, Handler: noop}) },
+		"AddGlobalAdvice":  func() error { return registry.AddGlobalAdvice(Advice{Type: Before, Handler: noop}) },
+		"AddPatternAdvice": func() error { return registry.AddPatternAdvice("Get*", Advice{Type: Before, Handler: noop}) },
+		"NewPattern":       func() error { return registry.AddPatternAdvice("List*", Advice{Type: Before, Handler: noop}) },
+		"ClearAdvice":      func() error { return registry.ClearAdvice("GetUser") },
+		"ClearAdviceType":  func() error { return registry.ClearAdviceType("GetUser", Before) },
+		"RemoveAdvice": func() error {
+			removed, err := registry.RemoveAdvice("GetUser", id)
+			if removed {
+				t.Error("expected RemoveAdvice to report false on a frozen chain")
+			}
+			return err
+		},
+		"RemoveAdviceWhere": func() error {
+			_, err := registry.RemoveAdviceWhere("GetUser", func(Advice) bool { return true })
+			return err
+		},
+		"RemoveTemporary": func() error {
+			_, err := registry.RemoveTemporary()
+			return err
+		},
+	}
+	for name, mutate := range mutators {
+		if err := mutate(); !errors.Is(err, ErrChainFrozen) {
+			t.Errorf("expected %s to fail with ErrChainFrozen, got %v", name, err)
+		}
+	}
+
+	getUser := Wrap1R(registry, "GetUser", func(id int) int { return id })
+	getUser(1)
+	if len(order) != 3 || order[0] != "auth" || order[1] != "trace" || order[2] != "log" {
+		t.Errorf("expected the frozen advice to run by priority, got %v", order)
+	}
+
+	if got := registry.EffectiveAdviceCount("ListUsers"); got != 0 {
+		t.Errorf("expected no advice for a new pattern, got %d", got)
+	}
+
+	// Functions registered afterwards start frozen
+	registry.MustRegister("ListUsers")
+	if err := registry.AddAdvice("ListUsers", Advice{Type: Before, Handler: noop}); !errors.Is(err, ErrChainFrozen) {
+		t.Errorf("expected a new function to start frozen, got %v", err)
+	}
+
+	// SetChain still swaps in a mutable chain
+	chain := NewAdviceChain()
+	chain.Add(Advice{Type: Before, Handler: noop, Temporary: true})
+	if err := registry.SetChain("ListUsers", chain); err != nil {
+		t.Fatalf("expected SetChain to succeed, got %v", err)
+	}
+	if removed, err := registry.RemoveTemporary(); removed != 1 || !errors.Is(err, ErrChainFrozen) {
+		t.Errorf("expected the mutable chain to be cleaned up despite frozen ones, got %d, %v", removed, err)
+	}
+
+	// Clear resets the registry to a mutable state
+	registry.Clear()
+	registry.MustRegister("GetUser")
+	if err := registry.AddAdvice("GetUser", Advice{Type: Before, Handler: noop}); err != nil {
+		t.Errorf("expected a cleared registry to be mutable, got %v", err)
+	}
+}
+
+func TestRegistry_FreezeAll_Concurrent(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	for priority := 0; priority < 3; priority++ {
+		registry.MustAddAdvice("GetUser", Advice{Type: Before, Priority: priority, Handler: func(c *Context) error { return nil }})
+	}
+	registry.FreezeAll()
+
+	getUser := Wrap1R(registry, "GetUser", func(id int) int { return id })
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got := getUser(i*100 + j); got != i*100+j {
+					t.Errorf("expected %d, got %d", i*100+j, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRegistry_FreezeAll_NoRegistryLock(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	noop := func(c *Context) error { return nil }
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Name: "log", Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 1, Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: Around, Handler: func(c *Context) error { return c.Proceed() }})
+	registry.AddGlobalAdvice(Advice{Type: After, Handler: noop})
+	registry.MustAddPatternAdvice("Get*", Advice{Type: Before, Handler: noop})
+	registry.OverridePriority("GetUser", "log", 10)
+	registry.SetRecursionPolicy("GetUser", RecursionError)
+	registry.SetMetadataStore(func() MetadataStore { return &countingStore{} })
+	registry.FreezeAll()
+
+	getUser := Wrap1R(registry, "GetUser", func(id int) int { return id })
+	getUser(1) // Memoize the lookups
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	done := make(chan int, 1)
+	go func() { done <- getUser(2) }()
+	select {
+	case got := <-done:
+		if got != 2 {
+			t.Errorf("expected 2, got %d", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a frozen call not to take the registry lock")
+	}
+}
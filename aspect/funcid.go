@@ -0,0 +1,99 @@
+// Package aspect - funcid provides integer handles for functions on ultra-hot paths
+package aspect
+
+import "fmt"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// FuncID is an integer handle of a registered function (see Registry.RegisterID).
+type FuncID int
+
+// funcIDSlot is the function behind a FuncID and its current advice chain (nil while unregistered).
+type funcIDSlot struct {
+	name  FuncKey
+	chain *AdviceChain
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// RegisterID registers a function if not already registered, like GetOrCreateChain, and returns
+// its integer handle for use with the ByID wrappers, e.g. Wrap1RByID. Registering the same name
+// again returns the same handle.
+//
+// Every wrapper memoizes its advice chain, shared advice and per-function settings, so a call by
+// key or by ID performs no registry lookup until the registry changes. A wrapper created by ID
+// then resolves its advice chain by indexing a slice instead of looking up the FuncKey in a map,
+// which only saves time while the registry keeps changing. The tradeoff: IDs are assigned in
+// registration order, so they are only meaningful for the registry that issued them and are not
+// stable across restarts; never persist them or pass them to another registry. A handle stays
+// valid when the function is unregistered or the registry cleared, and binds to the new chain
+// once the name is registered again.
+// Returns error if the function name is empty.
+func (registry *Registry) RegisterID(name FuncKey) (FuncID, error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if name == "" {
+		return 0, fmt.Errorf("function name cannot be empty")
+	}
+
+	chain := registry.chainOrCreate(name)
+	if id, exists := registry.funcIDIndex[name]; exists {
+		return id, nil
+	}
+
+	if registry.funcIDIndex == nil {
+		registry.funcIDIndex = make(map[FuncKey]FuncID)
+	}
+	id := FuncID(len(registry.funcIDs))
+	registry.funcIDs = append(registry.funcIDs, funcIDSlot{name: name, chain: chain})
+	registry.funcIDIndex[name] = id
+	return id, nil
+}
+
+// FuncKeyOf returns the name of the function behind id.
+func (registry *Registry) FuncKeyOf(id FuncID) (FuncKey, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	if id < 0 || int(id) >= len(registry.funcIDs) {
+		return "", false
+	}
+	return registry.funcIDs[id].name, true
+}
+
+// Wrap1RByID is Wrap1R for a function identified by an ID issued by registry.RegisterID.
+// It panics if the ID was not issued by registry.
+func Wrap1RByID[A, R any](registry *Registry, id FuncID, fn func(A) R) func(A) R {
+	return wrap1R(registry.newIDSite(id), fn)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// newIDSite creates the shared state of a function wrapped by ID.
+func (registry *Registry) newIDSite(id FuncID) *wrapSite {
+	funcKey, ok := registry.FuncKeyOf(id)
+	if !ok {
+		panic(fmt.Sprintf("aspect: FuncID %d was not issued by this registry", id))
+	}
+	return &wrapSite{registry: registry, funcKey: funcKey, id: id, byID: true}
+}
+
+// chainByID retrieves the advice chain of the function behind id.
+func (registry *Registry) chainByID(id FuncID) (*AdviceChain, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	slot := registry.funcIDs[id]
+	if slot.chain == nil {
+		return nil, fmt.Errorf("function '%s' is not registered", slot.name)
+	}
+	return slot.chain, nil
+}
+
+// bindFuncID points the ID of name, if any, to chain. The caller must hold the registry lock.
+func (registry *Registry) bindFuncID(name FuncKey, chain *AdviceChain) {
+	if id, exists := registry.funcIDIndex[name]; exists {
+		registry.funcIDs[id].chain = chain
+	}
+}
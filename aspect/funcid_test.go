@@ -0,0 +1,103 @@
+// Package aspect - funcid_test validates integer function handles
+package aspect
+
+import (
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_RegisterID(t *testing.T) {
+	registry := NewRegistry()
+
+	first, err := registry.RegisterID("First")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := registry.RegisterID("Second")
+	if first == second {
+		t.Errorf("expected distinct IDs, got %d for both", first)
+	}
+	if again, _ := registry.RegisterID("First"); again != first {
+		t.Errorf("expected the same ID for the same name, got %d and %d", first, again)
+	}
+	if !registry.IsRegistered("First") {
+		t.Error("expected RegisterID to register the function")
+	}
+	if name, ok := registry.FuncKeyOf(second); !ok || name != "Second" {
+		t.Errorf("expected 'Second', got %q (found=%v)", name, ok)
+	}
+	if _, ok := registry.FuncKeyOf(42); ok {
+		t.Error("expected an unknown ID not to be found")
+	}
+	if _, err := registry.RegisterID(""); err == nil {
+		t.Error("expected error for empty name")
+	}
+}
+
+func TestWrap1RByID(t *testing.T) {
+	registry := NewRegistry()
+	id, _ := registry.RegisterID("Double")
+	registry.MustAddAdvice("Double", Advice{Type: Around, Handler: func(c *Context) error {
+		if c.Arg(0).(int) < 0 {
+			c.SetResult(0, 0)
+			c.Skipped = true
+		}
+		return nil
+	}})
+
+	double := Wrap1RByID(registry, id, func(x int) int { return x * 2 })
+	if got := double(21); got != 42 {
+		t.Errorf("expected 42, got %d", got)
+	}
+	if got := double(-1); got != 0 {
+		t.Errorf("expected advice to apply by ID, got %d", got)
+	}
+
+	// The handle binds to the new chain once the function is registered again
+	registry.Unregister("Double")
+	registry.MustRegister("Double")
+	registry.MustAddAdvice("Double", Advice{Type: Around, Handler: func(c *Context) error {
+		c.SetResult(0, -1)
+		c.Skipped = true
+		return nil
+	}})
+	if got := double(21); got != -1 {
+		t.Errorf("expected the advice of the new chain, got %d", got)
+	}
+}
+
+func TestWrap1RByID_UnknownID(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for an ID not issued by the registry")
+		}
+	}()
+	Wrap1RByID(NewRegistry(), 3, func(x int) int { return x })
+}
+
+func TestWrap1RByID_NoRegistryLock(t *testing.T) {
+	registry := NewRegistry()
+	id, _ := registry.RegisterID("Double")
+	registry.MustAddAdvice("Double", Advice{Type: Before, Name: "log", Handler: func(c *Context) error { return nil }})
+	registry.MustAddPatternAdvice("Dou*", Advice{Type: After, Handler: func(c *Context) error { return nil }})
+	registry.OverridePriority("Double", "log", 10)
+
+	double := Wrap1RByID(registry, id, func(x int) int { return x * 2 })
+	double(1) // Memoize the lookups
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	done := make(chan int, 1)
+	go func() { done <- double(2) }()
+	select {
+	case got := <-done:
+		if got != 4 {
+			t.Errorf("expected 4, got %d", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a call by ID not to look up the registry")
+	}
+}
@@ -0,0 +1,95 @@
+// Package aspect - global provides registry-wide advice applied to every wrapped function
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AddGlobalAdvice adds advice that applies to every function executed through the registry.
+//
+// Global and per-function advice are merged by priority. At equal priority the
+// ordering follows onion semantics: global advice runs before per-function advice
+// for Before and Around, and after per-function advice for After, AfterReturning
+// and AfterThrowing, so global advice always encloses local advice.
+// Returns ErrChainFrozen once the registry is frozen (see FreezeAll).
+func (registry *Registry) AddGlobalAdvice(advice Advice) error {
+	return registry.globalChain().Add(advice)
+}
+
+// GlobalAdviceCount returns the number of global advice in the registry.
+func (registry *Registry) GlobalAdviceCount() int {
+	return registry.globalChain().Count()
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// adviceFor returns the advice of the given type to run for an invocation of funcKey,
+// merging the registry's global and pattern advice with the function's own advice.
+func (registry *Registry) adviceFor(funcKey FuncKey, chain *AdviceChain, adviceType AdviceType) []Advice {
+	if registry == nil || chain.resolved {
+		return chain.snapshot(adviceType)
+	}
+	return mergeShared(chain, registry.sharedChains(funcKey), adviceType)
+}
+
+// mergeShared returns the advice of the given type of chain merged with the advice of the
+// shared chains, which may be empty.
+func mergeShared(chain *AdviceChain, shared []*AdviceChain, adviceType AdviceType) []Advice {
+	local := chain.snapshot(adviceType)
+	if len(shared) == 0 || chain.resolved {
+		return local
+	}
+
+	// The list is sorted stably by priority, so insertion order decides ties:
+	// global, then pattern, then local advice on the way in, the reverse on the way out.
+	switch adviceType {
+	case Before, Around:
+		var merged []Advice
+		for _, sharedChain := range shared {
+			merged = append(merged, sharedChain.snapshot(adviceType)...)
+		}
+		if merged == nil {
+			return local // No shared advice of this type, so nothing to copy
+		}
+		return append(merged, local...)
+	default:
+		merged := local
+		for i := len(shared) - 1; i >= 0; i-- {
+			merged = append(merged, shared[i].snapshot(adviceType)...)
+		}
+		return merged
+	}
+}
+
+// sharedChains returns the non-empty chains shared with funcKey: the global chain
+// followed by the chains of all matching patterns, in registration order.
+func (registry *Registry) sharedChains(funcKey FuncKey) []*AdviceChain {
+	var shared []*AdviceChain
+	for _, chain := range registry.matchingChains(funcKey) {
+		if chain.Count() > 0 {
+			shared = append(shared, chain)
+		}
+	}
+	return shared
+}
+
+// matchingChains returns the chains shared with funcKey, including empty ones: the global
+// chain followed by the chains of all matching patterns, in registration order.
+func (registry *Registry) matchingChains(funcKey FuncKey) []*AdviceChain {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	shared := []*AdviceChain{registry.global}
+	for _, pattern := range registry.patterns {
+		if pattern.matches(funcKey) {
+			shared = append(shared, pattern.chain)
+		}
+	}
+	return shared
+}
+
+// globalChain returns the chain holding the registry's global advice.
+func (registry *Registry) globalChain() *AdviceChain {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return registry.global
+}
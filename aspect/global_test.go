@@ -0,0 +1,105 @@
+// Package aspect - global_test validates global advice and its ordering against per-function advice
+package aspect
+
+import (
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestGlobalAdvice_AppliesToAllFunctions(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var calls []FuncKey
+	registry.AddGlobalAdvice(Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			calls = append(calls, c.FunctionName)
+			return nil
+		},
+	})
+
+	Wrap0(registry, "GetUser", func() {})()
+	Wrap0(registry, "Unregistered", func() {})()
+
+	expected := []FuncKey{"GetUser", "Unregistered"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected global advice for %v, got %v", expected, calls)
+	}
+	if registry.GlobalAdviceCount() != 1 {
+		t.Errorf("expected 1 global advice, got %d", registry.GlobalAdviceCount())
+	}
+
+	registry.Clear()
+	if registry.GlobalAdviceCount() != 0 {
+		t.Errorf("expected Clear to remove global advice, got %d", registry.GlobalAdviceCount())
+	}
+}
+
+func TestGlobalAdvice_EqualPriorityOrdering(t *testing.T) {
+	tests := []struct {
+		name       string
+		adviceType AdviceType
+		panics     bool
+		expected   []string
+	}{
+		{name: "Before", adviceType: Before, expected: []string{"global", "local"}},
+		{name: "Around", adviceType: Around, expected: []string{"global", "local"}},
+		{name: "AfterReturning", adviceType: AfterReturning, expected: []string{"local", "global"}},
+		{name: "After", adviceType: After, expected: []string{"local", "global"}},
+		{name: "AfterThrowing", adviceType: AfterThrowing, panics: true, expected: []string{"local", "global"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("Auth")
+
+			var order []string
+			record := func(label string) AdviceFunc {
+				return func(c *Context) error {
+					order = append(order, label)
+					return nil
+				}
+			}
+
+			// Local advice is added first to prove ordering does not depend on insertion time.
+			registry.MustAddAdvice("Auth", Advice{Type: tt.adviceType, Priority: 10, Handler: record("local")})
+			registry.AddGlobalAdvice(Advice{Type: tt.adviceType, Priority: 10, Handler: record("global")})
+
+			Wrap0(registry, "Auth", func() {
+				if tt.panics {
+					panic("boom")
+				}
+			})()
+
+			if !reflect.DeepEqual(order, tt.expected) {
+				t.Errorf("expected order %v, got %v", tt.expected, order)
+			}
+		})
+	}
+}
+
+func TestGlobalAdvice_PriorityWinsOverScope(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Auth")
+
+	var order []string
+	registry.AddGlobalAdvice(Advice{Type: Before, Priority: 1, Handler: func(c *Context) error {
+		order = append(order, "global")
+		return nil
+	}})
+	registry.MustAddAdvice("Auth", Advice{Type: Before, Priority: 100, Handler: func(c *Context) error {
+		order = append(order, "local")
+		return nil
+	}})
+
+	Wrap0(registry, "Auth", func() {})()
+
+	expected := []string{"local", "global"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
@@ -0,0 +1,107 @@
+// Package aspect - group provides advice groups that can be toggled together at runtime
+package aspect
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// GroupStats holds the execution counters of an advice group.
+type GroupStats struct {
+	Fired   uint64 // Fired is the number of times advice of the group has been executed.
+	Skipped uint64 // Skipped is the number of times advice of the group has been skipped because the group was disabled.
+}
+
+// adviceGroup holds the runtime state of a single advice group.
+type adviceGroup struct {
+	disabled atomic.Bool
+	fired    atomic.Uint64
+	skipped  atomic.Uint64
+}
+
+// adviceGroups stores the advice groups known to a registry.
+type adviceGroups struct {
+	mu     sync.RWMutex
+	groups map[string]*adviceGroup
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetGroupEnabled enables or disables all advice belonging to the given group.
+// Advice of a disabled group is skipped during execution. Groups are enabled by default.
+func (registry *Registry) SetGroupEnabled(group string, enabled bool) {
+	registry.groups.get(group).disabled.Store(!enabled)
+}
+
+// IsGroupEnabled reports whether the given group is enabled.
+func (registry *Registry) IsGroupEnabled(group string) bool {
+	g := registry.groups.lookup(group)
+	return g == nil || !g.disabled.Load()
+}
+
+// GroupStats returns the execution counters of the given group.
+func (registry *Registry) GroupStats(group string) GroupStats {
+	g := registry.groups.lookup(group)
+	if g == nil {
+		return GroupStats{}
+	}
+	return GroupStats{
+		Fired:   g.fired.Load(),
+		Skipped: g.skipped.Load(),
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// lookup returns the group state, or nil if the group has never been seen.
+func (ag *adviceGroups) lookup(group string) *adviceGroup {
+	ag.mu.RLock()
+	defer ag.mu.RUnlock()
+
+	return ag.groups[group]
+}
+
+// get returns the group state, creating it if needed.
+func (ag *adviceGroups) get(group string) *adviceGroup {
+	if g := ag.lookup(group); g != nil {
+		return g
+	}
+
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+
+	if ag.groups == nil {
+		ag.groups = make(map[string]*adviceGroup)
+	}
+	g, exists := ag.groups[group]
+	if !exists {
+		g = &adviceGroup{}
+		ag.groups[group] = g
+	}
+	return g
+}
+
+// groupEnabled reports whether advice of the given group may run and counts it as skipped
+// otherwise. Advice without a group is always enabled and is not counted.
+func (registry *Registry) groupEnabled(group string) bool {
+	if group == "" {
+		return true
+	}
+
+	g := registry.groups.get(group)
+	if g.disabled.Load() {
+		g.skipped.Add(1)
+		return false
+	}
+	return true
+}
+
+// groupFired counts an execution of advice of the given group, once all checks passed.
+func (registry *Registry) groupFired(group string) {
+	if group == "" {
+		return
+	}
+	registry.groups.get(group).fired.Add(1)
+}
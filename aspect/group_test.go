@@ -0,0 +1,90 @@
+// Package aspect - group_test validates advice group toggling
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_SetGroupEnabled(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var fired []string
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:     Before,
+		Priority: 100,
+		Group:    "tracing",
+		Handler: func(c *Context) error {
+			fired = append(fired, "tracing")
+			return nil
+		},
+	})
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:     After,
+		Priority: 50,
+		Group:    "debug",
+		Handler: func(c *Context) error {
+			fired = append(fired, "debug")
+			return nil
+		},
+	})
+
+	wrapped := Wrap1R(registry, "GetUser", func(id int) int { return id })
+
+	wrapped(1)
+	if len(fired) != 2 {
+		t.Fatalf("expected both groups to fire, got %v", fired)
+	}
+
+	registry.SetGroupEnabled("debug", false)
+	if registry.IsGroupEnabled("debug") {
+		t.Fatal("expected 'debug' group to be disabled")
+	}
+
+	fired = nil
+	wrapped(2)
+	if len(fired) != 1 || fired[0] != "tracing" {
+		t.Fatalf("expected only 'tracing' advice to fire, got %v", fired)
+	}
+
+	registry.SetGroupEnabled("debug", true)
+	fired = nil
+	wrapped(3)
+	if len(fired) != 2 {
+		t.Fatalf("expected both groups to fire after re-enabling, got %v", fired)
+	}
+
+	if stats := registry.GroupStats("tracing"); stats.Fired != 3 || stats.Skipped != 0 {
+		t.Errorf("unexpected 'tracing' stats: %+v", stats)
+	}
+	if stats := registry.GroupStats("debug"); stats.Fired != 2 || stats.Skipped != 1 {
+		t.Errorf("unexpected 'debug' stats: %+v", stats)
+	}
+	if stats := registry.GroupStats("unknown"); stats != (GroupStats{}) {
+		t.Errorf("expected zero stats for unknown group, got %+v", stats)
+	}
+}
+
+func TestRegistry_GroupStats_ConditionFalse(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var fired int
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:      Before,
+		Group:     "debug",
+		Condition: func(c *Context) bool { return false },
+		Handler: func(c *Context) error {
+			fired++
+			return nil
+		},
+	})
+
+	Wrap0(registry, "GetUser", func() {})()
+	if fired != 0 {
+		t.Errorf("expected the advice not to run, ran %d times", fired)
+	}
+	if stats := registry.GroupStats("debug"); stats.Fired != 0 || stats.Skipped != 0 {
+		t.Errorf("expected no fired or skipped advice, got %+v", stats)
+	}
+}
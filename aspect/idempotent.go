@@ -0,0 +1,95 @@
+// Package aspect - idempotent provides Around advice running a target at most once per key
+package aspect
+
+import (
+	"sync"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// idempotentRecord is the outcome of an invocation replayed to repeated calls.
+type idempotentRecord struct {
+	results []any
+	err     error
+}
+
+// idempotentCall is an invocation in progress that concurrent calls with the same key wait for.
+type idempotentCall struct {
+	done   chan struct{}
+	record idempotentRecord
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Idempotent returns Around advice ensuring the target runs at most once per key within window,
+// e.g. for workers consuming an at-least-once queue. The key is computed from the invocation
+// by keyFunc (DefaultKey if nil). Repeated calls skip the target and receive the stored results and error.
+//
+// Unlike caching, which trades freshness for speed and may run the target again at any time,
+// idempotency is about correctness: the outcome of the first run, including its error, is
+// the outcome of every call with the same key until the window expires. Concurrent calls with
+// a key in progress wait for it instead of running the target (single-flight); a waiter whose
+// context is cancelled gives up with the context error. Outcomes of calls whose target did not
+// run or panicked are shared with the waiters but not stored.
+//
+// The advice should have a high priority so that it runs before other Around advice.
+func Idempotent(store CacheStore, keyFunc func(*Context) string, window time.Duration) AdviceFunc {
+	if keyFunc == nil {
+		keyFunc = DefaultKey
+	}
+
+	var mu sync.Mutex
+	inflight := make(map[string]*idempotentCall)
+
+	return func(c *Context) error {
+		key := keyFunc(c)
+
+		mu.Lock()
+		if val, exists := store.Get(key); exists {
+			if record, ok := val.(idempotentRecord); ok {
+				mu.Unlock()
+				replayRecord(c, record)
+				return nil
+			}
+		}
+		if call, exists := inflight[key]; exists {
+			mu.Unlock()
+			select {
+			case <-call.done:
+				replayRecord(c, call.record)
+				return nil
+			case <-c.Context().Done():
+				return c.Context().Err()
+			}
+		}
+
+		call := &idempotentCall{done: make(chan struct{})}
+		inflight[key] = call
+		mu.Unlock()
+
+		c.OnComplete(func(c *Context) {
+			record := idempotentRecord{results: append([]any(nil), c.Results...), err: c.Error}
+
+			mu.Lock()
+			if c.TargetRan() && !c.HasPanic() {
+				store.Set(key, record, window)
+			}
+			call.record = record
+			delete(inflight, key)
+			mu.Unlock()
+
+			close(call.done)
+		})
+		return nil
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// replayRecord skips the target and hands a stored outcome to the invocation.
+func replayRecord(c *Context, record idempotentRecord) {
+	c.Skipped = true
+	c.Results = append([]any(nil), record.results...)
+	c.Error = record.err
+}
@@ -0,0 +1,97 @@
+// Package aspect - idempotent_test validates at-most-once execution per idempotency key
+package aspect
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestIdempotent_ConcurrentSameKey(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Charge")
+	registry.MustAddAdvice("Charge", Advice{
+		Type:     Around,
+		Priority: 100,
+		Handler: Idempotent(NewMemoryCacheStore(), func(c *Context) string {
+			return c.Args[0].(string)
+		}, time.Minute),
+	})
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	charge := Wrap1RE(registry, "Charge", func(paymentID string) (int, error) {
+		runs.Add(1)
+		<-release
+		return 100, nil
+	})
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = charge("payment-1")
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond) // Let the callers pile up on the key in progress
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Errorf("expected target to run once, ran %d times", runs.Load())
+	}
+	for i, result := range results {
+		if result != 100 {
+			t.Errorf("caller %d: expected result 100, got %d", i, result)
+		}
+	}
+
+	// A later delivery of the same key replays the stored outcome
+	if result, err := charge("payment-1"); result != 100 || err != nil || runs.Load() != 1 {
+		t.Errorf("expected replayed result 100 without running, got %d, %v (runs=%d)", result, err, runs.Load())
+	}
+
+	// Another key runs the target
+	_, _ = charge("payment-2")
+	if runs.Load() != 2 {
+		t.Errorf("expected a different key to run the target, runs=%d", runs.Load())
+	}
+}
+
+func TestIdempotent_ReplaysErrorsWithinWindow(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Send")
+	registry.MustAddAdvice("Send", Advice{
+		Type: Around,
+		Handler: Idempotent(NewMemoryCacheStore(), func(c *Context) string {
+			return "message-1"
+		}, 30*time.Millisecond),
+	})
+
+	var runs int
+	send := Wrap0E(registry, "Send", func() error {
+		runs++
+		return errors.New("rejected")
+	})
+
+	if err := send(); err == nil || err.Error() != "rejected" {
+		t.Fatalf("expected 'rejected', got %v", err)
+	}
+	if err := send(); err == nil || err.Error() != "rejected" || runs != 1 {
+		t.Errorf("expected replayed error without running, got %v (runs=%d)", err, runs)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	_ = send()
+	if runs != 2 {
+		t.Errorf("expected the target to run again after the window, runs=%d", runs)
+	}
+}
@@ -0,0 +1,48 @@
+// Package aspect - install provides reusable aspects and one-call setup of wrapped functions
+package aspect
+
+// -------------------------------------------- Types --------------------------------------------
+
+// Aspect is a named, reusable bundle of advice, e.g. logging or metrics, applied to several
+// functions (see Install1RE).
+type Aspect struct {
+	Name   string   // Name identifies the aspect, e.g. "logging".
+	Advice []Advice // Advice is added to every function the aspect is applied to.
+}
+
+// NewAspect creates an aspect bundling the given advice.
+func NewAspect(name string, advice ...Advice) *Aspect {
+	return &Aspect{
+		Name:   name,
+		Advice: advice,
+	}
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Apply adds the advice of the aspect to a registered function.
+// Returns error if the function is not registered.
+func (aspect *Aspect) Apply(registry *Registry, funcKey FuncKey) error {
+	for _, advice := range aspect.Advice {
+		if err := registry.AddAdvice(funcKey, advice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Install1RE registers a function with one argument returning (result, error), applies the advice
+// of the given aspects in order and returns the wrapped function, the whole setup in one call:
+//
+//	var GetUser = aspect.Install1RE(registry, "GetUser", getUser, logging, metrics)
+//
+// It panics if the function is already registered, so a setup cannot install its advice twice.
+func Install1RE[A, R any](registry *Registry, funcKey FuncKey, impl func(A) (R, error), aspects ...*Aspect) func(A) (R, error) {
+	registry.MustRegister(funcKey)
+	for _, aspect := range aspects {
+		if err := aspect.Apply(registry, funcKey); err != nil {
+			panic(err)
+		}
+	}
+	return Wrap1RE(registry, funcKey, impl)
+}
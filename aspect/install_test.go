@@ -0,0 +1,62 @@
+// Package aspect - install_test validates aspects and one-call setup of wrapped functions
+package aspect
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// newLoggingAspect returns an aspect appending a line to log before and after every call.
+func newLoggingAspect(log *[]string) *Aspect {
+	return NewAspect("logging",
+		Advice{Type: Before, Handler: func(c *Context) error {
+			*log = append(*log, fmt.Sprintf("call %s(%v)", c.FunctionName, c.Args[0]))
+			return nil
+		}},
+		Advice{Type: After, Handler: func(c *Context) error {
+			*log = append(*log, fmt.Sprintf("done %s", c.FunctionName))
+			return nil
+		}},
+	)
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestInstall1RE(t *testing.T) {
+	registry := NewRegistry()
+
+	var log []string
+	getUser := Install1RE(registry, "GetUser", func(id int) (string, error) {
+		return fmt.Sprintf("user-%d", id), nil
+	}, newLoggingAspect(&log))
+
+	if !registry.IsRegistered("GetUser") {
+		t.Fatal("expected the function to be registered")
+	}
+	if count := registry.GetAdviceCount("GetUser"); count != 2 {
+		t.Errorf("expected the aspect's 2 advice, got %d", count)
+	}
+
+	user, err := getUser(42)
+	if err != nil || user != "user-42" {
+		t.Fatalf("unexpected result: %q, %v", user, err)
+	}
+	if got := strings.Join(log, "; "); got != "call GetUser(42); done GetUser" {
+		t.Errorf("expected the logging advice to run, got %q", got)
+	}
+}
+
+func TestInstall1RE_AlreadyRegistered(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic installing an already registered function")
+		}
+	}()
+	Install1RE(registry, "GetUser", func(id int) (string, error) { return "", nil })
+}
@@ -2,7 +2,10 @@
 package aspect
 
 import (
+	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -245,6 +248,116 @@ func TestIntegration_PanicRecoveryPattern(t *testing.T) {
 	registry.Clear()
 }
 
+func TestIntegration_AfterRunsWhenBeforePanics(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Cleanup")
+
+	var afterRan, throwingRan, targetRan bool
+	registry.MustAddAdvice("Cleanup", Advice{Type: Before, Handler: func(c *Context) error {
+		panic("before exploded")
+	}})
+	registry.MustAddAdvice("Cleanup", Advice{Type: AfterThrowing, Handler: func(c *Context) error {
+		throwingRan = true
+		return nil
+	}})
+	registry.MustAddAdvice("Cleanup", Advice{Type: After, Handler: func(c *Context) error {
+		afterRan = true
+		if c.PanicValue != "before exploded" {
+			t.Errorf("expected After advice to observe the panic, got %v", c.PanicValue)
+		}
+		return nil
+	}})
+
+	wrapped := Wrap0E(registry, "Cleanup", func() error {
+		targetRan = true
+		return nil
+	})
+
+	err := wrapped()
+
+	if !afterRan {
+		t.Error("expected After advice to run when Before advice panics")
+	}
+	if !throwingRan {
+		t.Error("expected AfterThrowing advice to run when Before advice panics")
+	}
+	if targetRan {
+		t.Error("expected target not to run")
+	}
+	// Panics are converted to errors rather than re-panicked
+	if err == nil || err.Error() != "panic recovered: before exploded" {
+		t.Errorf("expected the panic to surface as an error, got %v", err)
+	}
+}
+
+func TestIntegration_PhasePanicsContinueLifecycle(t *testing.T) {
+	tests := []struct {
+		name         string
+		phase        AdviceType
+		targetPanics bool
+		boundary     bool // boundary reports that the panic is recovered at the phase boundary.
+	}{
+		{name: "before", phase: Before},
+		{name: "around", phase: Around},
+		{name: "afterReturning", phase: AfterReturning, boundary: true},
+		{name: "afterThrowing", phase: AfterThrowing, targetPanics: true, boundary: true},
+		{name: "after", phase: After, boundary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("Lifecycle")
+
+			var reported []error
+			registry.OnAdviceError(func(c *Context, err error) {
+				reported = append(reported, err)
+			})
+
+			var afterRan bool
+			registry.MustAddAdvice("Lifecycle", Advice{Type: After, Priority: 100, Handler: func(c *Context) error {
+				afterRan = true
+				return nil
+			}})
+			registry.MustAddAdvice("Lifecycle", Advice{Type: tt.phase, Handler: func(c *Context) error {
+				panic(tt.name + " exploded")
+			}})
+
+			wrapped := Wrap0E(registry, "Lifecycle", func() error {
+				if tt.targetPanics {
+					panic("target exploded")
+				}
+				return nil
+			})
+
+			var err error
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("expected the panic to be contained, got %v", r)
+					}
+				}()
+				err = wrapped()
+			}()
+
+			if !afterRan {
+				t.Error("expected After advice to run")
+			}
+			if err == nil {
+				t.Fatal("expected the panic to surface as an error")
+			}
+			if tt.boundary {
+				if !errors.Is(err, ErrAdvicePanic) {
+					t.Errorf("expected ErrAdvicePanic, got %v", err)
+				}
+				if len(reported) != 1 || !errors.Is(reported[0], ErrAdvicePanic) {
+					t.Errorf("expected the panic to be reported to OnAdviceError, got %v", reported)
+				}
+			}
+		})
+	}
+}
+
 func TestIntegration_ErrorHandlingPattern(t *testing.T) {
 	registry := NewRegistry()
 	registry.Clear()
@@ -440,3 +553,55 @@ func TestIntegration_MetadataPassingBetweenAdvice(t *testing.T) {
 
 	registry.Clear()
 }
+
+func TestIntegration_ParallelAfter(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("ParallelAfter")
+	if err := registry.SetParallelAfter("ParallelAfter", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const handlers = 3
+	var started sync.WaitGroup
+	started.Add(handlers)
+	var completed atomic.Int32
+
+	for i := 0; i < handlers; i++ {
+		registry.MustAddAdvice("ParallelAfter", Advice{
+			Type: After,
+			Handler: func(c *Context) error {
+				started.Done()
+				// Every handler waits for the others: this only completes if they run concurrently
+				done := make(chan struct{})
+				go func() {
+					started.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					return errors.New("handlers did not run concurrently")
+				}
+
+				c.SetMetadataVal("mutated", true) // Only affects this handler's copy
+				completed.Add(1)
+				return nil
+			},
+		})
+	}
+
+	c := registry.Execute(context.Background(), "ParallelAfter", func(c *Context) {})
+	if c.Err() != nil {
+		t.Fatalf("unexpected error: %v", c.Err())
+	}
+	if completed.Load() != handlers {
+		t.Errorf("expected the call to wait for %d handlers, %d completed", handlers, completed.Load())
+	}
+	if _, mutated := c.GetMetadataVal("mutated"); mutated {
+		t.Error("expected handler changes to stay on the context copies")
+	}
+
+	if err := registry.SetParallelAfter("NonExistent", true); err == nil {
+		t.Error("expected error for unregistered function")
+	}
+}
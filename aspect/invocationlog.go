@@ -0,0 +1,85 @@
+// Package aspect - invocationlog keeps an in-memory ring buffer of recent invocations for debugging
+package aspect
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// InvocationRecord describes a completed invocation kept in the invocation log.
+type InvocationRecord struct {
+	FuncKey  FuncKey       // FuncKey is the invoked function.
+	Args     string        // Args is the arguments formatted with %v (see Context.ArgsString).
+	Outcome  Outcome       // Outcome is how the invocation ended.
+	Error    error         // Error is the error the invocation returned, if any.
+	Duration time.Duration // Duration is the wall time of the invocation, advice included.
+	Time     time.Time     // Time is when the invocation started.
+}
+
+// invocationLog is a fixed-size ring buffer of invocation records. Writers claim a slot with an
+// atomic counter and publish the record atomically, so recording never takes a lock.
+type invocationLog struct {
+	next    atomic.Uint64
+	records []atomic.Pointer[InvocationRecord]
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetInvocationLog keeps the last capacity invocations of the registry's wrapped functions in
+// memory, retrievable with RecentInvocations. It is a diagnostic for intermittent failures, not
+// persistent logging, and costs formatting the arguments of every call. Setting it discards the
+// records kept so far; zero or a negative capacity disables it.
+func (registry *Registry) SetInvocationLog(capacity int) {
+	if capacity <= 0 {
+		registry.invocationLog.Store(nil)
+		return
+	}
+	registry.invocationLog.Store(&invocationLog{records: make([]atomic.Pointer[InvocationRecord], capacity)})
+}
+
+// RecentInvocations returns the invocations kept by the invocation log, oldest first.
+// It returns nil if the log is disabled. Under concurrent calls the snapshot is best effort:
+// records written while it is taken may or may not be included.
+func (registry *Registry) RecentInvocations() []InvocationRecord {
+	log := registry.invocationLog.Load()
+	if log == nil {
+		return nil
+	}
+	return log.snapshot()
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// record adds a completed invocation to the log, overwriting the oldest record when full.
+func (log *invocationLog) record(c *Context, start time.Time, duration time.Duration) {
+	slot := (log.next.Add(1) - 1) % uint64(len(log.records))
+	log.records[slot].Store(&InvocationRecord{
+		FuncKey:  c.FunctionName,
+		Args:     c.ArgsString(),
+		Outcome:  c.Outcome(),
+		Error:    c.Error,
+		Duration: duration,
+		Time:     start,
+	})
+}
+
+// snapshot returns the records of the log, oldest first.
+func (log *invocationLog) snapshot() []InvocationRecord {
+	next := log.next.Load()
+	capacity := uint64(len(log.records))
+
+	first := uint64(0)
+	if next > capacity {
+		first = next - capacity
+	}
+
+	records := make([]InvocationRecord, 0, next-first)
+	for i := first; i < next; i++ {
+		if record := log.records[i%capacity].Load(); record != nil {
+			records = append(records, *record)
+		}
+	}
+	return records
+}
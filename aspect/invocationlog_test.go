@@ -0,0 +1,90 @@
+// Package aspect - invocationlog_test validates the in-memory log of recent invocations
+package aspect
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_InvocationLog(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Divide")
+
+	divide := Wrap2RE(registry, "Divide", func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		if a < 0 {
+			panic("negative")
+		}
+		return a / b, nil
+	})
+
+	_, _ = divide(1, 1) // Not recorded, the log is off
+	if records := registry.RecentInvocations(); records != nil {
+		t.Fatalf("expected no records while disabled, got %v", records)
+	}
+
+	registry.SetInvocationLog(3)
+	_, _ = divide(8, 2)
+	_, _ = divide(9, 3)
+	_, _ = divide(1, 0)
+	_, _ = divide(-4, 2)
+
+	records := registry.RecentInvocations()
+	if len(records) != 3 {
+		t.Fatalf("expected the 3 most recent invocations, got %d", len(records))
+	}
+
+	expected := []struct {
+		args    string
+		outcome Outcome
+	}{
+		{args: "[9 3]", outcome: OutcomeSuccess},
+		{args: "[1 0]", outcome: OutcomeError},
+		{args: "[-4 2]", outcome: OutcomePanic},
+	}
+	for i, exp := range expected {
+		record := records[i]
+		if record.FuncKey != "Divide" || record.Args != exp.args || record.Outcome != exp.outcome {
+			t.Errorf("record %d: expected Divide%s %v, got %s%s %v", i, exp.args, exp.outcome, record.FuncKey, record.Args, record.Outcome)
+		}
+		if record.Time.IsZero() {
+			t.Errorf("record %d: expected a timestamp", i)
+		}
+	}
+	if records[1].Error == nil {
+		t.Error("expected the failed invocation to keep its error")
+	}
+
+	registry.SetInvocationLog(0)
+	if records := registry.RecentInvocations(); records != nil {
+		t.Errorf("expected no records once disabled, got %v", records)
+	}
+}
+
+func TestRegistry_InvocationLog_Concurrent(t *testing.T) {
+	registry := NewRegistry()
+	registry.SetInvocationLog(16)
+	ping := Wrap0(registry, "Ping", func() {})
+
+	done := make(chan struct{})
+	for range 8 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for range 100 {
+				ping()
+				_ = registry.RecentInvocations()
+			}
+		}()
+	}
+	for range 8 {
+		<-done
+	}
+
+	if records := registry.RecentInvocations(); len(records) != 16 {
+		t.Errorf("expected a full log of 16 records, got %d", len(records))
+	}
+}
@@ -0,0 +1,48 @@
+// Package aspect - keys provides default key functions for the caching and deduplication helpers
+package aspect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// DefaultKey builds a key from the function name and all arguments of the invocation, e.g.
+// `GetUser("alice", 3)`. Arguments are formatted with fmt's %#v, which uses reflection and is slow
+// compared to a hand-written key function; equal arguments produce equal keys, but pointers are
+// keyed by address, not by the value they point to. It is the key function the helpers use when
+// given none.
+func DefaultKey(c *Context) string {
+	return formatKey(c.FunctionName, c.Args)
+}
+
+// FieldsKey returns a key function like DefaultKey that only uses the arguments at the given
+// positions, e.g. FieldsKey(0) to key a call by its first argument and ignore the rest.
+// Positions out of range are keyed as nil.
+func FieldsKey(indices ...int) func(*Context) string {
+	return func(c *Context) string {
+		fields := make([]any, len(indices))
+		for i, index := range indices {
+			fields[i] = c.Arg(index)
+		}
+		return formatKey(c.FunctionName, fields)
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// formatKey formats a function name and values as a call expression.
+func formatKey(funcKey FuncKey, values []any) string {
+	var key strings.Builder
+	key.WriteString(string(funcKey))
+	key.WriteByte('(')
+	for i, val := range values {
+		if i > 0 {
+			key.WriteString(", ")
+		}
+		fmt.Fprintf(&key, "%#v", val)
+	}
+	key.WriteByte(')')
+	return key.String()
+}
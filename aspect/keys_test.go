@@ -0,0 +1,60 @@
+// Package aspect - keys_test validates the default key functions
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestDefaultKey(t *testing.T) {
+	type query struct {
+		Name  string
+		Limit int
+	}
+
+	key := func(funcKey FuncKey, args ...any) string {
+		return DefaultKey(NewContext(funcKey, args...))
+	}
+
+	if key("Search", "go", query{"x", 1}) != key("Search", "go", query{"x", 1}) {
+		t.Error("expected identical args to produce identical keys")
+	}
+
+	distinct := []string{
+		key("Search", "go", query{"x", 1}),
+		key("Search", "go", query{"x", 2}),
+		key("Search", "rust", query{"x", 1}),
+		key("Search", "1"),
+		key("Search", 1),
+		key("Find", "1"),
+		key("Search"),
+	}
+	seen := make(map[string]bool)
+	for _, k := range distinct {
+		if seen[k] {
+			t.Errorf("expected different args to produce different keys, got duplicate %s", k)
+		}
+		seen[k] = true
+	}
+
+	if got := key("GetUser", "alice", 3); got != `GetUser("alice", 3)` {
+		t.Errorf("expected a call expression key, got %s", got)
+	}
+}
+
+func TestFieldsKey(t *testing.T) {
+	byFirst := FieldsKey(0)
+
+	a := byFirst(NewContext("GetUser", "alice", "trace-1"))
+	b := byFirst(NewContext("GetUser", "alice", "trace-2"))
+	c := byFirst(NewContext("GetUser", "bob", "trace-1"))
+
+	if a != b {
+		t.Errorf("expected ignored arguments not to affect the key, got %s and %s", a, b)
+	}
+	if a == c {
+		t.Errorf("expected keyed arguments to affect the key, got %s twice", a)
+	}
+	if got := FieldsKey(1, 5)(NewContext("GetUser", "alice", 3)); got != "GetUser(3, <nil>)" {
+		t.Errorf("expected out of range positions to key as nil, got %s", got)
+	}
+}
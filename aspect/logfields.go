@@ -0,0 +1,26 @@
+// Package aspect - logfields provides structured logging fields describing an invocation
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// LogFields returns key-value pairs describing the invocation for structured loggers taking
+// alternating keys and values, such as slog and zap's SugaredLogger:
+//
+//	logger.Info("call completed", c.LogFields()...)
+//
+// The pairs are "func", "outcome" (see Outcome), "skipped", "error" (nil on success) and
+// "duration" (see Elapsed), followed by "panic" with the panic value if the invocation panicked.
+// Called before the target ran, e.g. from Before advice, the outcome and duration are provisional.
+func (c *Context) LogFields() []any {
+	fields := []any{
+		"func", string(c.FunctionName),
+		"outcome", c.Outcome().String(),
+		"skipped", c.Skipped,
+		"error", c.Error,
+		"duration", c.Elapsed(),
+	}
+	if c.HasPanic() {
+		fields = append(fields, "panic", c.PanicValue)
+	}
+	return fields
+}
@@ -0,0 +1,68 @@
+// Package aspect - logfields_test validates the structured logging fields of an invocation
+package aspect
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// logFieldMap checks that fields come in string-keyed pairs and returns them as a map.
+func logFieldMap(t *testing.T, fields []any) map[string]any {
+	t.Helper()
+	if len(fields)%2 != 0 {
+		t.Fatalf("expected key-value pairs, got %d fields", len(fields))
+	}
+	pairs := make(map[string]any, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			t.Fatalf("expected a string key at %d, got %v", i, fields[i])
+		}
+		pairs[key] = fields[i+1]
+	}
+	return pairs
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_LogFields(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+
+	getUser := Wrap1REx(registry, "GetUser", func(id int) (string, error) {
+		switch {
+		case id < 0:
+			panic("negative id")
+		case id == 0:
+			return "", errors.New("not found")
+		}
+		time.Sleep(time.Millisecond)
+		return "alice", nil
+	})
+
+	_, _, c := getUser(1)
+	fields := logFieldMap(t, c.LogFields())
+	if fields["func"] != "GetUser" || fields["outcome"] != "Success" || fields["skipped"] != false || fields["error"] != nil {
+		t.Errorf("unexpected fields for a successful call: %v", fields)
+	}
+	if duration, ok := fields["duration"].(time.Duration); !ok || duration < time.Millisecond {
+		t.Errorf("expected the duration of the call, got %v", fields["duration"])
+	}
+	if _, ok := fields["panic"]; ok {
+		t.Error("expected no panic field for a call that did not panic")
+	}
+
+	_, _, c = getUser(0)
+	if fields := logFieldMap(t, c.LogFields()); fields["outcome"] != "Error" || fields["error"] == nil {
+		t.Errorf("unexpected fields for a failed call: %v", fields)
+	}
+
+	_, _, c = getUser(-1)
+	if fields := logFieldMap(t, c.LogFields()); fields["outcome"] != "Panic" || fields["panic"] != "negative id" {
+		t.Errorf("unexpected fields for a panicking call: %v", fields)
+	}
+}
@@ -0,0 +1,58 @@
+// Package aspect - maintenance short-circuits all wrapped calls of a registry, e.g. while draining
+package aspect
+
+import (
+	"context"
+	"errors"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrMaintenance is returned by wrapped calls while their registry is in maintenance mode
+// without a fallback.
+var ErrMaintenance = errors.New("service in maintenance")
+
+// -------------------------------------------- Types --------------------------------------------
+
+// maintenanceMode holds the fallback of a registry in maintenance.
+type maintenanceMode struct {
+	fallback func(*Context)
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// EnterMaintenance puts the registry in maintenance mode: every wrapped call is short-circuited,
+// running neither advice nor the target. Instead fallback runs with the call's context and can
+// set results (see SetResult) or an error; the context is marked Skipped, so results it sets are
+// returned. With a nil fallback, calls fail with ErrMaintenance. Unlike disabling advice groups,
+// this stops the targets too, which is useful for graceful draining. Calls already running are
+// not affected.
+func (registry *Registry) EnterMaintenance(fallback func(*Context)) {
+	registry.maintenance.Store(&maintenanceMode{fallback: fallback})
+}
+
+// ExitMaintenance ends maintenance mode, so wrapped calls run normally again.
+func (registry *Registry) ExitMaintenance() {
+	registry.maintenance.Store(nil)
+}
+
+// InMaintenance reports whether the registry is in maintenance mode.
+func (registry *Registry) InMaintenance() bool {
+	return registry.maintenance.Load() != nil
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// runFallback short-circuits an invocation during maintenance.
+func (mode *maintenanceMode) runFallback(registry *Registry, ctx context.Context, funcKey FuncKey, args ...any) *Context {
+	c := NewContextWithContext(ctx, funcKey, args...)
+	c.registry = registry
+	c.Skipped = true
+
+	if mode.fallback == nil {
+		c.Error = ErrMaintenance
+		return c
+	}
+	mode.fallback(c)
+	return c
+}
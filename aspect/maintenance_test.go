@@ -0,0 +1,51 @@
+// Package aspect - maintenance_test validates short-circuiting wrapped calls during maintenance
+package aspect
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_Maintenance(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var beforeCount, targetCount int
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		beforeCount++
+		return nil
+	}})
+	getUser := Wrap1RE(registry, "GetUser", func(id int) (string, error) {
+		targetCount++
+		return "alice", nil
+	})
+
+	registry.EnterMaintenance(func(c *Context) {
+		c.SetResult(0, "placeholder")
+	})
+	if !registry.InMaintenance() {
+		t.Error("expected the registry to be in maintenance")
+	}
+	if user, err := getUser(1); user != "placeholder" || err != nil {
+		t.Errorf("expected the fallback result, got %q, %v", user, err)
+	}
+
+	registry.EnterMaintenance(nil)
+	if _, err := getUser(1); !errors.Is(err, ErrMaintenance) {
+		t.Errorf("expected ErrMaintenance without a fallback, got %v", err)
+	}
+
+	if beforeCount != 0 || targetCount != 0 {
+		t.Errorf("expected neither advice nor target to run, got %d advice and %d target calls", beforeCount, targetCount)
+	}
+
+	registry.ExitMaintenance()
+	if user, err := getUser(1); user != "alice" || err != nil {
+		t.Errorf("expected normal behavior after maintenance, got %q, %v", user, err)
+	}
+	if beforeCount != 1 || targetCount != 1 {
+		t.Errorf("expected advice and target to run again, got %d advice and %d target calls", beforeCount, targetCount)
+	}
+}
@@ -0,0 +1,117 @@
+// Package aspect - metadata provides pluggable storage for context metadata
+package aspect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// -------------------------------------------- Global Variables --------------------------------------------
+
+// ErrMetadataLimit is reported when advice adds a metadata entry beyond the registry's limit.
+var ErrMetadataLimit = errors.New("metadata entry limit exceeded")
+
+// -------------------------------------------- Types --------------------------------------------
+
+// MetadataStore stores the metadata of a single invocation.
+// Implementations must be safe for concurrent use.
+type MetadataStore interface {
+	Get(key string) (any, bool)
+	Set(key string, val any)
+	Range(fn func(key string, val any) bool) // Range stops when fn returns false.
+	Clear()
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetMetadataStore sets a factory creating the metadata store of every invocation executed
+// through the registry. By default metadata lives in the Context.Metadata map.
+//
+// With a custom store, SetMetadataVal, GetMetadataVal, RangeMetadata and ClearMetadata go through
+// the store, while Context.Metadata stays an empty map: advice must use the accessors rather than
+// the map directly. Passing nil restores the default.
+func (registry *Registry) SetMetadataStore(factory func() MetadataStore) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.metadataStore = factory
+	registry.generation.Add(1)
+}
+
+// SetMaxMetadataEntries caps the number of metadata entries per invocation, protecting the hot
+// path from advice that keeps adding keys. New keys beyond the limit are dropped and reported
+// through the OnAdviceError handler as ErrMetadataLimit; existing keys can still be updated.
+// The limit is applied when an invocation starts. Zero or a negative value disables it.
+func (registry *Registry) SetMaxMetadataEntries(n int) {
+	registry.maxMetadata.Store(int64(max(n, 0)))
+}
+
+// MaxMetadataEntries returns the metadata entry limit per invocation (0 means unlimited).
+func (registry *Registry) MaxMetadataEntries() int {
+	return int(registry.maxMetadata.Load())
+}
+
+// RangeMetadata calls fn for each metadata entry until fn returns false.
+func (c *Context) RangeMetadata(fn func(key string, val any) bool) {
+	if c.store != nil {
+		c.store.Range(fn)
+		return
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for key, val := range c.Metadata {
+		if !fn(key, val) {
+			return
+		}
+	}
+}
+
+// ClearMetadata removes all metadata entries.
+func (c *Context) ClearMetadata() {
+	if c.store != nil {
+		c.store.Clear()
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.Metadata)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// newMetadataStore creates a metadata store for an invocation, or nil to use the default map.
+func (registry *Registry) newMetadataStore() MetadataStore {
+	registry.mu.RLock()
+	factory := registry.metadataStore
+	registry.mu.RUnlock()
+
+	if factory == nil {
+		return nil
+	}
+	return factory()
+}
+
+// storeAccepts reports whether the custom store can take key without exceeding the entry limit.
+func (c *Context) storeAccepts(key string) bool {
+	if _, exists := c.store.Get(key); exists {
+		return true
+	}
+
+	count := 0
+	c.store.Range(func(string, any) bool {
+		count++
+		return count < c.maxMetadata
+	})
+	return count < c.maxMetadata
+}
+
+// reportMetadataLimit reports a dropped metadata entry to the registry, if any.
+func (c *Context) reportMetadataLimit(key string) {
+	if c.registry != nil {
+		c.registry.reportAdviceError(c, fmt.Errorf("%w: %d entries, dropped '%s'", ErrMetadataLimit, c.maxMetadata, key))
+	}
+}
@@ -0,0 +1,161 @@
+// Package aspect - metadata_test validates pluggable metadata storage
+package aspect
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// countingStore is a MetadataStore backed by sync.Map that counts writes.
+type countingStore struct {
+	entries sync.Map
+	mu      sync.Mutex
+	sets    int
+}
+
+func (s *countingStore) Get(key string) (any, bool) { return s.entries.Load(key) }
+
+func (s *countingStore) Set(key string, val any) {
+	s.mu.Lock()
+	s.sets++
+	s.mu.Unlock()
+	s.entries.Store(key, val)
+}
+
+func (s *countingStore) Range(fn func(key string, val any) bool) {
+	s.entries.Range(func(key, val any) bool { return fn(key.(string), val) })
+}
+
+func (s *countingStore) Clear() { s.entries.Clear() }
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_SetMetadataStore(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Traced")
+
+	var stores []*countingStore
+	registry.SetMetadataStore(func() MetadataStore {
+		store := &countingStore{}
+		stores = append(stores, store)
+		return store
+	})
+
+	registry.MustAddAdvice("Traced", Advice{Type: Before, Handler: func(c *Context) error {
+		c.SetMetadataVal("trace_id", "abc")
+		return nil
+	}})
+
+	var traceID any
+	registry.MustAddAdvice("Traced", Advice{Type: After, Handler: func(c *Context) error {
+		traceID, _ = c.GetMetadataVal("trace_id")
+		return nil
+	}})
+
+	c := registry.Execute(context.Background(), "Traced", func(c *Context) {})
+
+	if traceID != "abc" {
+		t.Errorf("expected advice to share metadata through the store, got %v", traceID)
+	}
+	if len(stores) != 1 || stores[0].sets != 1 {
+		t.Fatalf("expected one store with one write, got %d stores", len(stores))
+	}
+	if len(c.Metadata) != 0 {
+		t.Errorf("expected the Metadata map to stay unused, got %v", c.Metadata)
+	}
+
+	var keys []string
+	c.RangeMetadata(func(key string, val any) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 1 || keys[0] != "trace_id" {
+		t.Errorf("expected Range to visit trace_id, got %v", keys)
+	}
+
+	clone := c.Clone()
+	if val, _ := clone.GetMetadataVal("trace_id"); val != "abc" {
+		t.Errorf("expected clone to copy store entries, got %v", val)
+	}
+
+	c.ClearMetadata()
+	if _, exists := c.GetMetadataVal("trace_id"); exists {
+		t.Error("expected metadata to be cleared")
+	}
+	if _, exists := clone.GetMetadataVal("trace_id"); !exists {
+		t.Error("expected clone store to be independent")
+	}
+
+	// Restoring the default uses the Metadata map again
+	registry.SetMetadataStore(nil)
+	c = registry.Execute(context.Background(), "Traced", func(c *Context) {})
+	if c.Metadata["trace_id"] != "abc" {
+		t.Errorf("expected default map storage, got %v", c.Metadata)
+	}
+}
+
+func TestContext_RangeAndClearMetadata(t *testing.T) {
+	c := NewContext("test")
+	c.SetMetadataVal("a", 1)
+	c.SetMetadataVal("b", 2)
+
+	visited := 0
+	c.RangeMetadata(func(key string, val any) bool {
+		visited++
+		return false // Stop after the first entry
+	})
+	if visited != 1 {
+		t.Errorf("expected Range to stop early, visited %d", visited)
+	}
+
+	c.ClearMetadata()
+	if len(c.Metadata) != 0 {
+		t.Errorf("expected empty metadata, got %v", c.Metadata)
+	}
+}
+
+func TestRegistry_SetMaxMetadataEntries(t *testing.T) {
+	for _, custom := range []bool{false, true} {
+		registry := NewRegistry()
+		registry.MustRegister("Chatty")
+		registry.SetMaxMetadataEntries(2)
+		if custom {
+			registry.SetMetadataStore(func() MetadataStore { return &countingStore{} })
+		}
+
+		var reported []error
+		registry.OnAdviceError(func(c *Context, err error) {
+			reported = append(reported, err)
+		})
+
+		registry.MustAddAdvice("Chatty", Advice{Type: Before, Handler: func(c *Context) error {
+			c.SetMetadataVal("a", 1)
+			c.SetMetadataVal("b", 2)
+			c.SetMetadataVal("c", 3)  // Dropped
+			c.SetMetadataVal("a", 10) // Updating an existing key is allowed
+			return nil
+		}})
+
+		c := registry.Execute(context.Background(), "Chatty", func(c *Context) {})
+
+		if _, exists := c.GetMetadataVal("c"); exists {
+			t.Errorf("custom=%v: expected entry beyond the limit to be dropped", custom)
+		}
+		if val, _ := c.GetMetadataVal("a"); val != 10 {
+			t.Errorf("custom=%v: expected existing key to be updated, got %v", custom, val)
+		}
+		if len(reported) != 1 || !errors.Is(reported[0], ErrMetadataLimit) {
+			t.Errorf("custom=%v: expected one ErrMetadataLimit report, got %v", custom, reported)
+		}
+	}
+
+	registry := NewRegistry()
+	registry.SetMaxMetadataEntries(-1)
+	if registry.MaxMetadataEntries() != 0 {
+		t.Errorf("expected negative limit to disable the cap, got %d", registry.MaxMetadataEntries())
+	}
+}
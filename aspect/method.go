@@ -0,0 +1,19 @@
+// Package aspect - method provides wrapping methods whose receiver is resolved at call time
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// WrapMethodLate wraps a method with one argument returning (result, error), resolving the
+// receiver through getReceiver on every call instead of capturing it once. Wrapping a method
+// value (e.g. service.GetUser) binds the receiver at wrap time, so swapping the service later,
+// e.g. for a test double, goes unnoticed; with WrapMethodLate the wrapped function stays stable
+// while the implementation behind it can be hot-swapped:
+//
+//	getUser := aspect.WrapMethodLate(registry, "UserService.GetUser",
+//		func() *UserService { return currentService },
+//		func(s *UserService) func(string) (*User, error) { return s.GetUser })
+func WrapMethodLate[T, A, R any](registry *Registry, funcKey FuncKey, getReceiver func() *T, method func(*T) func(A) (R, error)) func(A) (R, error) {
+	return Wrap1RE(registry, funcKey, func(a A) (R, error) {
+		return method(getReceiver())(a)
+	})
+}
@@ -0,0 +1,52 @@
+// Package aspect - method_test validates wrapping methods with a late-bound receiver
+package aspect
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWrapMethodLate_SwapsReceiver(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Greeter.Greet")
+
+	var beforeCount int
+	registry.MustAddAdvice("Greeter.Greet", Advice{Type: Before, Handler: func(c *Context) error {
+		beforeCount++
+		return nil
+	}})
+
+	var current atomic.Pointer[greeter]
+	current.Store(&greeter{prefix: "hello"})
+
+	greet := WrapMethodLate(registry, "Greeter.Greet",
+		current.Load,
+		func(g *greeter) func(string) (string, error) { return g.Greet })
+
+	if got, _ := greet("alice"); got != "hello alice" {
+		t.Errorf("expected 'hello alice', got %q", got)
+	}
+
+	current.Store(&greeter{prefix: "hi"})
+	if got, _ := greet("bob"); got != "hi bob" {
+		t.Errorf("expected the swapped receiver to answer 'hi bob', got %q", got)
+	}
+
+	if beforeCount != 2 {
+		t.Errorf("expected advice to run for both calls, got %d", beforeCount)
+	}
+}
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// greeter is a service whose instance is swapped during tests.
+type greeter struct {
+	prefix string
+}
+
+// Greet greets name with the greeter's prefix.
+func (g *greeter) Greet(name string) (string, error) {
+	return g.prefix + " " + name, nil
+}
@@ -0,0 +1,61 @@
+// Package aspect - metrics exports invocation statistics in the Prometheus text exposition format
+package aspect
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// labelEscaper escapes label values as required by the text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// WriteMetrics writes the invocation statistics of all functions (see EnableStats) to w in the
+// Prometheus/OpenMetrics text exposition format, so a plain /metrics handler can serve them:
+//
+//	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+//		_ = registry.WriteMetrics(w)
+//	})
+//
+// Every series carries the function key in the func label.
+func (registry *Registry) WriteMetrics(w io.Writer) error {
+	funcKeys := registry.statsKeys()
+	stats := make([]FunctionStats, len(funcKeys))
+	for i, funcKey := range funcKeys {
+		stats[i] = registry.Stats(funcKey)
+	}
+
+	bw := bufio.NewWriter(w)
+	writeFamily := func(name, metricType, help string, value func(FunctionStats) string) {
+		fmt.Fprintf(bw, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, metricType)
+		for i, funcKey := range funcKeys {
+			fmt.Fprintf(bw, "%s{func=\"%s\"} %s\n", name, labelEscaper.Replace(string(funcKey)), value(stats[i]))
+		}
+	}
+
+	writeFamily("aspect_calls_total", "counter", "Total invocations of wrapped functions.",
+		func(s FunctionStats) string { return fmt.Sprint(s.Calls) })
+	writeFamily("aspect_errors_total", "counter", "Invocations that returned an error without panicking.",
+		func(s FunctionStats) string { return fmt.Sprint(s.Errors) })
+	writeFamily("aspect_panics_total", "counter", "Invocations that panicked.",
+		func(s FunctionStats) string { return fmt.Sprint(s.Panics) })
+
+	fmt.Fprintf(bw, "# HELP aspect_call_duration_seconds Wall time of invocations, advice included.\n")
+	fmt.Fprintf(bw, "# TYPE aspect_call_duration_seconds summary\n")
+	for i, funcKey := range funcKeys {
+		label := labelEscaper.Replace(string(funcKey))
+		fmt.Fprintf(bw, "aspect_call_duration_seconds_sum{func=\"%s\"} %g\n", label, stats[i].TotalDuration.Seconds())
+		fmt.Fprintf(bw, "aspect_call_duration_seconds_count{func=\"%s\"} %d\n", label, stats[i].Calls)
+	}
+
+	writeFamily("aspect_call_duration_max_seconds", "gauge", "Longest invocation of wrapped functions.",
+		func(s FunctionStats) string { return fmt.Sprintf("%g", s.MaxDuration.Seconds()) })
+
+	return bw.Flush()
+}
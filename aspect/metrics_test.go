@@ -0,0 +1,52 @@
+// Package aspect - metrics_test validates the text exposition of invocation statistics
+package aspect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_WriteMetrics(t *testing.T) {
+	registry := NewRegistry()
+	registry.EnableStats(true)
+	registry.MustRegister("GetUser")
+
+	getUser := Wrap1E(registry, "GetUser", func(id int) error {
+		switch id {
+		case 0:
+			return errors.New("not found")
+		case -1:
+			panic("corrupt id")
+		}
+		return nil
+	})
+	for _, id := range []int{1, 2, 3, 0, -1} {
+		_ = getUser(id)
+	}
+	Wrap0(registry, `Say"Hi"`, func() {})()
+
+	var sb strings.Builder
+	if err := registry.WriteMetrics(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metrics := sb.String()
+
+	for _, expected := range []string{
+		"# TYPE aspect_calls_total counter\n",
+		`aspect_calls_total{func="GetUser"} 5` + "\n",
+		`aspect_errors_total{func="GetUser"} 1` + "\n",
+		`aspect_panics_total{func="GetUser"} 1` + "\n",
+		"# TYPE aspect_call_duration_seconds summary\n",
+		`aspect_call_duration_seconds_count{func="GetUser"} 5` + "\n",
+		`aspect_call_duration_seconds_sum{func="GetUser"} `,
+		`aspect_call_duration_max_seconds{func="GetUser"} `,
+		`aspect_calls_total{func="Say\"Hi\""} 1` + "\n",
+	} {
+		if !strings.Contains(metrics, expected) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", expected, metrics)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// Package aspect - middleware adapts advice chains to the func(next) next middleware shape
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AsMiddleware returns a middleware running the advice of funcKey around the next function,
+// so advice chains can slot into existing stacks of func() error middleware:
+//
+//	handler := AsMiddleware(registry, "auth")(AsMiddleware(registry, "audit")(target))
+//
+// The call is bare: the context has no arguments and no results, and the error of next, possibly
+// replaced by advice, is returned. Each middleware is equivalent to Wrap0E on next.
+func AsMiddleware(registry *Registry, funcKey FuncKey) func(next func() error) func() error {
+	return func(next func() error) func() error {
+		return Wrap0E(registry, funcKey, next)
+	}
+}
@@ -0,0 +1,49 @@
+// Package aspect - middleware_test validates advice chains used as func middleware
+package aspect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAsMiddleware(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("auth")
+	registry.MustRegister("audit")
+
+	var order []string
+	record := func(label string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, label)
+			return nil
+		}
+	}
+	registry.MustAddAdvice("auth", Advice{Type: Before, Handler: record("auth-before")})
+	registry.MustAddAdvice("auth", Advice{Type: After, Handler: record("auth-after")})
+	registry.MustAddAdvice("audit", Advice{Type: Before, Handler: record("audit-before")})
+	registry.MustAddAdvice("audit", Advice{Type: After, Handler: record("audit-after")})
+
+	errTarget := errors.New("target failed")
+	target := func() error {
+		order = append(order, "target")
+		return errTarget
+	}
+
+	chain := []func(func() error) func() error{AsMiddleware(registry, "auth"), AsMiddleware(registry, "audit")}
+	handler := target
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](handler)
+	}
+
+	if err := handler(); !errors.Is(err, errTarget) {
+		t.Errorf("expected the target error, got %v", err)
+	}
+
+	expected := []string{"auth-before", "audit-before", "target", "audit-after", "auth-after"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
@@ -0,0 +1,67 @@
+// Package aspect - multi provides wrapping a function with the combined advice of several registries
+package aspect
+
+import "fmt"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Wrap1REMulti wraps a function with one argument returning (result, error) with the combined
+// advice of all given registries for funcKey, e.g. a framework registry and an application registry.
+//
+// Advice from all registries (including their global and pattern advice) is merged by priority.
+// At equal priority, registries listed first enclose the later ones: their advice runs first for
+// Before and Around and last for AfterReturning, After and AfterThrowing. Registry-level settings
+// (groups, hooks, timeouts, metadata store) are taken from the first registry.
+// The merged chain is rebuilt on every call. Panics if no registry is given.
+func Wrap1REMulti[A, R any](registries []*Registry, funcKey FuncKey, fn func(A) (R, error)) func(A) (R, error) {
+	if len(registries) == 0 {
+		panic("at least one registry is required")
+	}
+
+	site := newWrapSite(registries[0], funcKey)
+	site.registries = registries
+	return func(a A) (R, error) {
+		var result R
+		var err error
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
+			result, err = fn(a)
+			c.SetResult(0, result)
+			c.Error = err
+		}, a)
+		return resolveResultError(c, result, err)
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// mergeChains flattens the effective advice of funcKey in all registries into a single resolved chain.
+func mergeChains(registries []*Registry, funcKey FuncKey) (*AdviceChain, error) {
+	merged := NewAdviceChain()
+	merged.resolved = true
+
+	for _, adviceType := range []AdviceType{Before, Around, AfterReturning, After, AfterThrowing} {
+		for i := range registries {
+			registry := registries[i]
+			if adviceType != Before && adviceType != Around {
+				registry = registries[len(registries)-1-i] // Onion ordering on the way out
+			}
+
+			chain, err := registry.GetAdviceChain(funcKey)
+			if err != nil {
+				chain = NewAdviceChain()
+			}
+			for _, advice := range registry.adviceFor(funcKey, chain, adviceType) {
+				merged.Add(advice)
+			}
+		}
+	}
+
+	if merged.Count() == 0 {
+		return nil, fmt.Errorf("function '%s' has no advice in any registry", funcKey)
+	}
+	return merged, nil
+}
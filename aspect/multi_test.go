@@ -0,0 +1,68 @@
+// Package aspect - multi_test validates wrapping with the advice of several registries
+package aspect
+
+import (
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWrap1REMulti(t *testing.T) {
+	framework := NewRegistry()
+	app := NewRegistry()
+	framework.MustRegister("GetUser")
+	app.MustRegister("GetUser")
+
+	var order []string
+	record := func(label string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, label)
+			return nil
+		}
+	}
+
+	framework.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 100, Handler: record("framework-auth")})
+	framework.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 10, Handler: record("framework-log")})
+	framework.MustAddAdvice("GetUser", Advice{Type: After, Priority: 10, Handler: record("framework-after")})
+	framework.AddGlobalAdvice(Advice{Type: Before, Priority: 200, Handler: record("framework-global")})
+	app.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 50, Handler: record("app-validate")})
+	app.MustAddAdvice("GetUser", Advice{Type: Before, Priority: 10, Handler: record("app-log")})
+	app.MustAddAdvice("GetUser", Advice{Type: After, Priority: 10, Handler: record("app-after")})
+
+	getUser := Wrap1REMulti([]*Registry{framework, app}, "GetUser", func(id int) (string, error) {
+		order = append(order, "target")
+		return "alice", nil
+	})
+
+	name, err := getUser(1)
+	if err != nil || name != "alice" {
+		t.Fatalf("unexpected result: %q, %v", name, err)
+	}
+
+	expected := []string{
+		"framework-global", "framework-auth", "app-validate", "framework-log", "app-log",
+		"target",
+		"app-after", "framework-after",
+	}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestWrap1REMulti_NoAdvice(t *testing.T) {
+	double := Wrap1REMulti([]*Registry{NewRegistry(), NewRegistry()}, "Double", func(x int) (int, error) {
+		return x * 2, nil
+	})
+
+	if got, err := double(21); err != nil || got != 42 {
+		t.Errorf("expected 42, got %d, %v", got, err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic without registries")
+		}
+	}()
+	Wrap1REMulti[int, int](nil, "Double", nil)
+}
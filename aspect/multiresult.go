@@ -0,0 +1,94 @@
+// Package aspect - multiresult provides wrappers for functions returning several results
+package aspect
+
+import "fmt"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Wrap1R2 wraps a function with one argument and two return values.
+//
+// Around advice skipping the target must set both results (indices 0 and 1). A skip setting only
+// some of them is reported to the OnAdviceError handler as ErrSkipWithoutResult, naming the
+// missing index, and the caller receives the zero value for it. A skip setting none is reported
+// only with SetWarnSkipNoResult, as for single-result wrappers.
+func Wrap1R2[A, R1, R2 any](registry *Registry, funcKey FuncKey, fn func(A) (R1, R2)) func(A) (R1, R2) {
+	site := newWrapSite(registry, funcKey)
+	return func(a A) (R1, R2) {
+		var result1 R1
+		var result2 R2
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
+			result1, result2 = fn(a)
+			c.SetResult(0, result1)
+			c.SetResult(1, result2)
+		}, a)
+
+		result1, result2, missing := resolveResults2(c, result1, result2)
+		if missing != nil {
+			c.registry.reportAdviceError(c, missing)
+		}
+		return result1, result2
+	}
+}
+
+// Wrap1R2E wraps a function with one argument and returns (result, result, error).
+//
+// Like Wrap1R2, but a skip setting only some of the results returns ErrSkipWithoutResult to the
+// caller instead of reporting it, unless the advice set an error.
+func Wrap1R2E[A, R1, R2 any](registry *Registry, funcKey FuncKey, fn func(A) (R1, R2, error)) func(A) (R1, R2, error) {
+	site := newWrapSite(registry, funcKey)
+	return func(a A) (R1, R2, error) {
+		var result1 R1
+		var result2 R2
+		var err error
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
+			result1, result2, err = fn(a)
+			c.SetResult(0, result1)
+			c.SetResult(1, result2)
+			c.Error = err
+		}, a)
+
+		result1, result2, missing := resolveResults2(c, result1, result2)
+		if err = resolveError(c, err); err == nil && missing != nil {
+			err = missing
+		}
+		return result1, result2, err
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// resolveResults2 extracts the two results of a skipped invocation from the context. missing is
+// non-nil when the advice set some of the results but not all of them and no error.
+func resolveResults2[R1, R2 any](c *Context, original1 R1, original2 R2) (R1, R2, error) {
+	if c == nil || !c.Skipped {
+		return original1, original2, nil
+	}
+
+	result1, ok1 := ResultAs[R1](c, 0)
+	result2, ok2 := ResultAs[R2](c, 1)
+	switch {
+	case c.Error != nil:
+		return result1, result2, nil
+	case !ok1 && !ok2:
+		c.registry.warnSkipNoResult(c)
+		return original1, original2, nil
+	case !ok1:
+		return original1, result2, missingResultError(c, 0)
+	case !ok2:
+		return result1, original2, missingResultError(c, 1)
+	}
+	return result1, result2, nil
+}
+
+// missingResultError describes a result left unset by advice skipping the target.
+func missingResultError(c *Context, index int) error {
+	return fmt.Errorf("%w: '%s' result %d not set", ErrSkipWithoutResult, c.FunctionName, index)
+}
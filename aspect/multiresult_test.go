@@ -0,0 +1,111 @@
+// Package aspect - multiresult_test validates the wrappers of functions returning several results
+package aspect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// skipWith installs Around advice skipping the target after setting the given results by index.
+func skipWith(registry *Registry, funcKey FuncKey, results map[int]any) {
+	registry.MustAddAdvice(funcKey, Advice{
+		Type: Around,
+		Handler: func(c *Context) error {
+			for index, result := range results {
+				c.SetResult(index, result)
+			}
+			c.Skipped = true
+			return nil
+		},
+	})
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWrap1R2(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Split")
+
+	split := Wrap1R2(registry, "Split", func(s string) (string, int) { return strings.ToUpper(s), len(s) })
+	if upper, length := split("abc"); upper != "ABC" || length != 3 {
+		t.Errorf("expected (ABC, 3), got (%s, %d)", upper, length)
+	}
+
+	skipWith(registry, "Split", map[int]any{0: "cached", 1: 6})
+	if upper, length := split("abc"); upper != "cached" || length != 6 {
+		t.Errorf("expected the results set by advice, got (%s, %d)", upper, length)
+	}
+}
+
+func TestWrap1R2_SkipWithMissingResult(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Split")
+	skipWith(registry, "Split", map[int]any{0: "cached"})
+
+	var warnings []error
+	registry.OnAdviceError(func(c *Context, err error) {
+		warnings = append(warnings, err)
+	})
+
+	split := Wrap1R2(registry, "Split", func(s string) (string, int) { return s, len(s) })
+	upper, length := split("abc")
+	if upper != "cached" || length != 0 {
+		t.Errorf("expected (cached, 0), got (%s, %d)", upper, length)
+	}
+	if len(warnings) != 1 || !errors.Is(warnings[0], ErrSkipWithoutResult) {
+		t.Fatalf("expected an ErrSkipWithoutResult warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0].Error(), "result 1") {
+		t.Errorf("expected the warning to name the missing index, got %v", warnings[0])
+	}
+}
+
+func TestWrap1R2_SkipWithoutResults(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Split")
+	skipWith(registry, "Split", nil)
+
+	var warnings []error
+	registry.OnAdviceError(func(c *Context, err error) {
+		warnings = append(warnings, err)
+	})
+
+	split := Wrap1R2(registry, "Split", func(s string) (string, int) { return s, len(s) })
+	split("abc")
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warning when disabled, got %v", warnings)
+	}
+
+	registry.SetWarnSkipNoResult(true)
+	split("abc")
+	if len(warnings) != 1 || !errors.Is(warnings[0], ErrSkipWithoutResult) {
+		t.Errorf("expected an ErrSkipWithoutResult warning, got %v", warnings)
+	}
+}
+
+func TestWrap1R2E_SkipWithMissingResult(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Split")
+	skipWith(registry, "Split", map[int]any{1: 6})
+
+	split := Wrap1R2E(registry, "Split", func(s string) (string, int, error) { return s, len(s), nil })
+	upper, length, err := split("abc")
+	if !errors.Is(err, ErrSkipWithoutResult) {
+		t.Errorf("expected ErrSkipWithoutResult, got %v", err)
+	}
+	if upper != "" || length != 6 {
+		t.Errorf("expected (\"\", 6), got (%q, %d)", upper, length)
+	}
+
+	// An error set by the advice takes precedence
+	registry.MustAddAdvice("Split", Advice{Type: Around, Priority: -1, Handler: func(c *Context) error {
+		c.Error = errors.New("unavailable")
+		return nil
+	}})
+	if _, _, err := split("abc"); err == nil || err.Error() != "unavailable" {
+		t.Errorf("expected the advice's error, got %v", err)
+	}
+}
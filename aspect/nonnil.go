@@ -0,0 +1,63 @@
+// Package aspect - nonnil provides Before advice guarding against nil arguments
+package aspect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrNilArgument is the error of a call rejected by RequireNonNil.
+var ErrNilArgument = errors.New("nil argument")
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// RequireNonNil returns Before advice rejecting calls where any argument at the given indices is
+// nil, so nil pointers fail with a descriptive error instead of panicking in the target:
+//
+//	registry.MustAddAdvice("SaveUser", aspect.Advice{Type: aspect.Before, Handler: aspect.RequireNonNil(0)})
+//
+// Nil interfaces and nil pointers, slices, maps, channels and functions are rejected, as are
+// indices beyond the arguments. Without indices all arguments are checked. The error wraps
+// ErrNilArgument.
+func RequireNonNil(indices ...int) AdviceFunc {
+	return func(c *Context) error {
+		if len(indices) == 0 {
+			for i := range c.Args {
+				if isNil(c.Args[i]) {
+					return nilArgumentError(c, i)
+				}
+			}
+			return nil
+		}
+
+		for _, i := range indices {
+			if i < 0 || i >= len(c.Args) || isNil(c.Args[i]) {
+				return nilArgumentError(c, i)
+			}
+		}
+		return nil
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// isNil reports whether val is nil, including typed nil values of nillable kinds.
+func isNil(val any) bool {
+	if val == nil {
+		return true
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// nilArgumentError describes the nil argument at index of the invocation.
+func nilArgumentError(c *Context, index int) error {
+	return fmt.Errorf("%w: argument %d of %s", ErrNilArgument, index, c.FunctionName)
+}
@@ -0,0 +1,74 @@
+// Package aspect - nonnil_test validates the nil argument guard
+package aspect
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// testUser is a pointer argument of the guarded function.
+type testUser struct {
+	Name string
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRequireNonNil(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("SaveUser")
+	registry.MustAddAdvice("SaveUser", Advice{Type: Before, Handler: RequireNonNil(0, 1)})
+
+	var saved int
+	saveUser := Wrap2E(registry, "SaveUser", func(user *testUser, audit fmt.Stringer) error {
+		saved++
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		user    *testUser
+		audit   fmt.Stringer
+		wantErr string
+	}{
+		{name: "nil pointer", user: nil, audit: LazyArgs(NewContext("audit")), wantErr: "nil argument: argument 0 of SaveUser"},
+		{name: "nil interface", user: &testUser{Name: "alice"}, audit: nil, wantErr: "nil argument: argument 1 of SaveUser"},
+		{name: "non-nil", user: &testUser{Name: "alice"}, audit: LazyArgs(NewContext("audit"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved = 0
+			err := saveUser(tt.user, tt.audit)
+			if tt.wantErr == "" {
+				if err != nil || saved != 1 {
+					t.Errorf("expected the call to pass, got %v (saved=%d)", err, saved)
+				}
+				return
+			}
+			if !errors.Is(err, ErrNilArgument) || saved != 0 {
+				t.Errorf("expected ErrNilArgument without running the target, got %v (saved=%d)", err, saved)
+			}
+			var adviceErr *AdviceError
+			if !errors.As(err, &adviceErr) || adviceErr.Err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRequireNonNil_AllArguments(t *testing.T) {
+	check := RequireNonNil()
+	var nilMap map[string]int
+
+	if err := check(NewContext("Fn", 1, "x", []int{})); err != nil {
+		t.Errorf("expected non-nil arguments to pass, got %v", err)
+	}
+	if err := check(NewContext("Fn", 1, nilMap)); !errors.Is(err, ErrNilArgument) {
+		t.Errorf("expected a nil map to be rejected, got %v", err)
+	}
+	if err := RequireNonNil(2)(NewContext("Fn", 1)); !errors.Is(err, ErrNilArgument) {
+		t.Errorf("expected a missing argument to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Package aspect - options provides per-wrap-site behavior for the *Opts wrapper variants
+package aspect
+
+import (
+	"context"
+	"fmt"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// WrapOption configures the behavior of a single wrap site, so two wrappers of the same function
+// can behave differently, e.g. a background caller swallowing panics as errors while a request
+// caller re-panics. Site options take precedence over the registry-level settings.
+type WrapOption func(*wrapOptions)
+
+// wrapOptions is the per-site configuration built from WrapOptions.
+type wrapOptions struct {
+	repanic   bool
+	recursion *RecursionPolicy // recursion overrides the registry's recursion policy when set.
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// WithPanicAsError returns recovered panics of the target as errors. This is the engine's
+// default, so on its own it changes nothing; it only undoes an earlier WithRepanic, e.g. when
+// appended to a shared list of options.
+func WithPanicAsError() WrapOption {
+	return func(options *wrapOptions) {
+		options.repanic = false
+	}
+}
+
+// WithRepanic re-panics with the panic value once the lifecycle of a panicking invocation
+// completed, i.e. after AfterThrowing and After advice ran, instead of returning it as an error.
+// A value replaced by AfterThrowing advice is the one re-panicked.
+func WithRepanic() WrapOption {
+	return func(options *wrapOptions) {
+		options.repanic = true
+	}
+}
+
+// WithReentrantSuppression runs nested calls of the function made while it executes directly,
+// without advice, as RecursionBypass does for the registry (see SetRecursionPolicy). Nested
+// calls are detected through the context, so it requires a context-aware wrapper such as
+// Wrap1RECtxOpts; wrappers without a context panic when given it.
+func WithReentrantSuppression() WrapOption {
+	return func(options *wrapOptions) {
+		policy := RecursionBypass
+		options.recursion = &policy
+	}
+}
+
+// Wrap1REOpts is Wrap1RE with per-wrap-site options.
+// Panics if given WithReentrantSuppression, which needs a context to detect nested calls.
+func Wrap1REOpts[A, R any](registry *Registry, funcKey FuncKey, fn func(A) (R, error), opts ...WrapOption) func(A) (R, error) {
+	site := newWrapSiteOpts(registry, funcKey, opts)
+	if site.options.recursion != nil {
+		panic(fmt.Sprintf("WithReentrantSuppression requires a context-aware wrapper for '%s', use Wrap1RECtxOpts", funcKey))
+	}
+	return wrap1RE(site, fn)
+}
+
+// Wrap1RECtxOpts is Wrap1RECtx with per-wrap-site options.
+func Wrap1RECtxOpts[A, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A) (R, error), opts ...WrapOption) func(context.Context, A) (R, error) {
+	return wrap1RECtx(newWrapSiteOpts(registry, funcKey, opts), fn)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// newWrapSiteOpts creates the shared state of a wrapped function with per-site options.
+func newWrapSiteOpts(registry *Registry, funcKey FuncKey, opts []WrapOption) *wrapSite {
+	site := newWrapSite(registry, funcKey)
+	for _, opt := range opts {
+		opt(&site.options)
+	}
+	return site
+}
@@ -0,0 +1,94 @@
+// Package aspect - options_test validates per-wrap-site options
+package aspect
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWrap1REOpts_PanicHandlingPerSite(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Process")
+
+	var lifecycle []string
+	registry.MustAddAdvice("Process", Advice{Type: AfterThrowing, Handler: func(c *Context) error {
+		lifecycle = append(lifecycle, "afterThrowing")
+		return nil
+	}})
+	registry.MustAddAdvice("Process", Advice{Type: After, Handler: func(c *Context) error {
+		lifecycle = append(lifecycle, "after")
+		return nil
+	}})
+
+	process := func(job string) (int, error) { panic("corrupt job " + job) }
+	background := Wrap1REOpts(registry, "Process", process, WithRepanic(), WithPanicAsError())
+	request := Wrap1REOpts(registry, "Process", process, WithRepanic())
+
+	if _, err := background("a"); err == nil || !strings.Contains(err.Error(), "corrupt job a") {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+
+	lifecycle = nil
+	func() {
+		defer func() {
+			if r := recover(); r != "corrupt job b" {
+				t.Errorf("expected the request site to re-panic with the original value, got %v", r)
+			}
+		}()
+		_, _ = request("b")
+		t.Error("expected the request site to panic")
+	}()
+	if strings.Join(lifecycle, ",") != "afterThrowing,after" {
+		t.Errorf("expected the lifecycle to complete before re-panicking, got %v", lifecycle)
+	}
+}
+
+func TestWrap1RECtxOpts_ReentrantSuppression(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Load")
+
+	var advised int
+	var load func(context.Context, string) (string, error)
+	registry.MustAddAdvice("Load", Advice{Type: Around, Handler: func(c *Context) error {
+		advised++
+		if c.Arg(0) == "page" {
+			_, _ = load(c.Context(), "warmup") // Re-enters through advice
+		}
+		return nil
+	}})
+
+	target := func(ctx context.Context, key string) (string, error) { return key, nil }
+
+	load = Wrap1RECtxOpts(registry, "Load", target, WithReentrantSuppression())
+	if result, err := load(context.Background(), "page"); result != "page" || err != nil {
+		t.Fatalf("expected 'page', got %q, %v", result, err)
+	}
+	if advised != 1 {
+		t.Errorf("expected the nested call to run without advice, advice ran %d times", advised)
+	}
+
+	// Another site of the same function keeps the registry's policy
+	advised = 0
+	load = Wrap1RECtxOpts(registry, "Load", target)
+	_, _ = load(context.Background(), "page")
+	if advised != 2 {
+		t.Errorf("expected the nested call to be advised without the option, advice ran %d times", advised)
+	}
+}
+
+func TestWrap1REOpts_ReentrantSuppressionRejected(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Resolve")
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "Wrap1RECtxOpts") {
+			t.Errorf("expected a panic pointing to the context-aware wrapper, got %v", r)
+		}
+	}()
+	Wrap1REOpts(registry, "Resolve", func(name string) (string, error) { return name, nil },
+		WithReentrantSuppression(), WithPanicAsError())
+	t.Error("expected Wrap1REOpts to reject WithReentrantSuppression")
+}
@@ -0,0 +1,71 @@
+// Package aspect - outcome summarizes how an invocation ended
+package aspect
+
+import (
+	"context"
+	"errors"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+const (
+	OutcomeSuccess   Outcome = iota // OutcomeSuccess means the target ran and returned without error.
+	OutcomeError                    // OutcomeError means the invocation failed with an error other than a cancellation.
+	OutcomePanic                    // OutcomePanic means a panic was recovered.
+	OutcomeSkipped                  // OutcomeSkipped means Around advice skipped the target.
+	OutcomeAborted                  // OutcomeAborted means advice stopped the invocation before the target ran.
+	OutcomeCancelled                // OutcomeCancelled means the context was cancelled or its deadline exceeded.
+)
+
+// outcomeNames maps outcomes to their names.
+var outcomeNames = map[Outcome]string{
+	OutcomeSuccess:   "Success",
+	OutcomeError:     "Error",
+	OutcomePanic:     "Panic",
+	OutcomeSkipped:   "Skipped",
+	OutcomeAborted:   "Aborted",
+	OutcomeCancelled: "Cancelled",
+}
+
+// -------------------------------------------- Types --------------------------------------------
+
+// Outcome summarizes how an invocation ended, so logging advice can switch on a single value.
+type Outcome int
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Outcome computes the outcome of the invocation from the context state. When several apply,
+// the first of panic, error, cancellation, skip and abort wins; an error that is the context's
+// cancellation or deadline counts as a cancellation. Within After advice the error of a failed
+// Before or Around phase is not set yet, so such an invocation reports OutcomeAborted there.
+func (c *Context) Outcome() Outcome {
+	switch {
+	case c.HasPanic():
+		return OutcomePanic
+	case c.Error != nil && !isCancellation(c.Error):
+		return OutcomeError
+	case c.Error != nil, !c.targetRan && !c.Skipped && c.Context().Err() != nil:
+		return OutcomeCancelled
+	case c.Skipped:
+		return OutcomeSkipped
+	case !c.targetRan:
+		return OutcomeAborted
+	default:
+		return OutcomeSuccess
+	}
+}
+
+// String returns the name of the outcome.
+func (outcome Outcome) String() string {
+	if name, exists := outcomeNames[outcome]; exists {
+		return name
+	}
+	return "Unknown"
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// isCancellation reports whether err stems from a cancelled or expired context.
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
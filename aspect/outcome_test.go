@@ -0,0 +1,116 @@
+// Package aspect - outcome_test validates the invocation outcome computed from the context
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_Outcome(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		advice   *Advice
+		target   func(ctx context.Context) error
+		expected Outcome
+	}{
+		{
+			name:     "success",
+			target:   func(ctx context.Context) error { return nil },
+			expected: OutcomeSuccess,
+		},
+		{
+			name:     "error",
+			target:   func(ctx context.Context) error { return errors.New("boom") },
+			expected: OutcomeError,
+		},
+		{
+			name:     "panic",
+			target:   func(ctx context.Context) error { panic("boom") },
+			expected: OutcomePanic,
+		},
+		{
+			name: "panic wins over error",
+			advice: &Advice{Type: AfterThrowing, Handler: func(c *Context) error {
+				c.Error = errors.New("boom")
+				return nil
+			}},
+			target:   func(ctx context.Context) error { panic("boom") },
+			expected: OutcomePanic,
+		},
+		{
+			name: "skipped",
+			advice: &Advice{Type: Around, Handler: func(c *Context) error {
+				c.Skipped = true
+				return nil
+			}},
+			target:   func(ctx context.Context) error { return nil },
+			expected: OutcomeSkipped,
+		},
+		{
+			name:     "cancelled",
+			ctx:      cancelled,
+			advice:   &Advice{Type: Before, Handler: func(c *Context) error { return nil }},
+			target:   func(ctx context.Context) error { return nil },
+			expected: OutcomeCancelled,
+		},
+		{
+			name:     "target returning context error",
+			target:   func(ctx context.Context) error { return context.DeadlineExceeded },
+			expected: OutcomeCancelled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("Op")
+			if tt.advice != nil {
+				registry.MustAddAdvice("Op", *tt.advice)
+			}
+
+			var outcome Outcome
+			registry.MustAddAdvice("Op", Advice{Type: After, Priority: -100, Handler: func(c *Context) error {
+				outcome = c.Outcome()
+				return nil
+			}})
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			_ = Wrap0ECtx(registry, "Op", tt.target)(ctx)
+
+			if outcome != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, outcome)
+			}
+		})
+	}
+}
+
+func TestContext_Outcome_Aborted(t *testing.T) {
+	c := NewContext("Op")
+	if c.Outcome() != OutcomeAborted {
+		t.Errorf("expected %v for a target that never ran, got %v", OutcomeAborted, c.Outcome())
+	}
+
+	c.Error = errors.New("denied")
+	if c.Outcome() != OutcomeError {
+		t.Errorf("expected %v once the error is set, got %v", OutcomeError, c.Outcome())
+	}
+}
+
+func TestOutcome_String(t *testing.T) {
+	if OutcomeCancelled.String() != "Cancelled" {
+		t.Errorf("expected 'Cancelled', got %q", OutcomeCancelled.String())
+	}
+	if Outcome(99).String() != "Unknown" {
+		t.Errorf("expected 'Unknown', got %q", Outcome(99).String())
+	}
+}
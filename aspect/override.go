@@ -0,0 +1,76 @@
+// Package aspect - override provides temporary priority overrides of named advice
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// OverridePriority makes the advice named name (see Advice.Name) run with the given priority
+// for invocations of funcKey, without touching the registered advice. It is meant for
+// debugging, e.g. forcing a specific advice to run first; ClearOverride restores the
+// registered priority. Global and pattern advice can be overridden per function too.
+func (registry *Registry) OverridePriority(funcKey FuncKey, name string, priority int) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	// Maps are replaced, never mutated, so executions can read them without holding the lock
+	overrides := make(map[string]int, len(registry.overrides[funcKey])+1)
+	for existingName, existingPriority := range registry.overrides[funcKey] {
+		overrides[existingName] = existingPriority
+	}
+	overrides[name] = priority
+
+	if registry.overrides == nil {
+		registry.overrides = make(map[FuncKey]map[string]int)
+	}
+	registry.overrides[funcKey] = overrides
+	registry.generation.Add(1)
+}
+
+// ClearOverride removes the priority override of the advice named name for funcKey.
+// Does nothing if there is no such override.
+func (registry *Registry) ClearOverride(funcKey FuncKey, name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, exists := registry.overrides[funcKey][name]; !exists {
+		return
+	}
+	registry.generation.Add(1)
+
+	overrides := make(map[string]int, len(registry.overrides[funcKey]))
+	for existingName, existingPriority := range registry.overrides[funcKey] {
+		if existingName != name {
+			overrides[existingName] = existingPriority
+		}
+	}
+
+	if len(overrides) == 0 {
+		delete(registry.overrides, funcKey)
+		return
+	}
+	registry.overrides[funcKey] = overrides
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// priorityOverrides returns the priority overrides of funcKey, or nil if there are none.
+// Safe to call with a nil registry.
+func (registry *Registry) priorityOverrides(funcKey FuncKey) map[string]int {
+	if registry == nil {
+		return nil
+	}
+
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return registry.overrides[funcKey]
+}
+
+// effectivePriority returns the priority advice runs with, honoring an override of its name.
+func effectivePriority(advice Advice, overrides map[string]int) int {
+	if advice.Name != "" {
+		if priority, exists := overrides[advice.Name]; exists {
+			return priority
+		}
+	}
+	return advice.Priority
+}
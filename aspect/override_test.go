@@ -0,0 +1,51 @@
+// Package aspect - override_test validates temporary priority overrides of named advice
+package aspect
+
+import (
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_OverridePriority(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Checkout")
+
+	var order []string
+	record := func(name string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	registry.MustAddAdvice("Checkout", Advice{Type: Before, Priority: 100, Name: "auth", Handler: record("auth")})
+	registry.MustAddAdvice("Checkout", Advice{Type: Before, Priority: 50, Name: "validate", Handler: record("validate")})
+	registry.MustAddAdvice("Checkout", Advice{Type: Before, Priority: 10, Name: "trace", Handler: record("trace")})
+
+	checkout := Wrap0(registry, "Checkout", func() {})
+	run := func() string {
+		order = nil
+		checkout()
+		return strings.Join(order, ",")
+	}
+
+	if got := run(); got != "auth,validate,trace" {
+		t.Fatalf("expected registered order, got %s", got)
+	}
+
+	registry.OverridePriority("Checkout", "trace", 1000)
+	if got := run(); got != "trace,auth,validate" {
+		t.Errorf("expected overridden advice to run first, got %s", got)
+	}
+	if plan := registry.ExecutionPlan("Checkout"); plan[0].Name != "trace" || plan[0].Priority != 1000 {
+		t.Errorf("expected the plan to reflect the override, got %+v", plan[0])
+	}
+
+	registry.ClearOverride("Checkout", "trace")
+	if got := run(); got != "auth,validate,trace" {
+		t.Errorf("expected registered order after clearing the override, got %s", got)
+	}
+
+	registry.ClearOverride("Checkout", "unknown") // No-op
+}
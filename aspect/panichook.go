@@ -0,0 +1,53 @@
+// Package aspect - panichook provides a process-wide hook notified of every recovered panic
+package aspect
+
+import (
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// panicHookBox holds the process-wide panic hook so it can be swapped atomically.
+type panicHookBox struct {
+	hook func(funcKey FuncKey, value any, stack []byte)
+}
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// anyPanicHook is the hook set with OnAnyPanic.
+var anyPanicHook atomic.Pointer[panicHookBox]
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// OnAnyPanic sets a process-wide hook notified whenever the engine recovers a panic of a wrapped
+// function or its Before/Around advice, regardless of the registry, e.g. to report crashes to
+// Sentry. It complements per-function AfterThrowing advice and runs before it, with the original
+// panic value and the stack of the panicking goroutine captured at recovery time.
+//
+// The hook runs synchronously on the panicking call; a panic of the hook itself is swallowed.
+// Functions without any advice are called directly and their panics are not recovered, so they
+// do not reach the hook. Passing nil removes the hook.
+func OnAnyPanic(hook func(funcKey FuncKey, value any, stack []byte)) {
+	if hook == nil {
+		anyPanicHook.Store(nil)
+		return
+	}
+	anyPanicHook.Store(&panicHookBox{hook: hook})
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// notifyPanic reports a recovered panic to the hook set with OnAnyPanic, if any. It must be
+// called from the deferred function recovering the panic, so the stack shows the panic site.
+func notifyPanic(funcKey FuncKey, value any) {
+	box := anyPanicHook.Load()
+	if box == nil {
+		return
+	}
+
+	defer func() {
+		_ = recover() // A failing crash reporter must not take the call down with it
+	}()
+	box.hook(funcKey, value, debug.Stack())
+}
@@ -0,0 +1,59 @@
+// Package aspect - panichook_test validates the process-wide panic hook
+package aspect
+
+import (
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// explodingTarget panics from a named frame the captured stack is expected to show.
+func explodingTarget(id int) (string, error) {
+	panic("boom")
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestOnAnyPanic(t *testing.T) {
+	var funcKey FuncKey
+	var value any
+	var stack []byte
+	OnAnyPanic(func(k FuncKey, v any, s []byte) {
+		funcKey, value, stack = k, v, s
+	})
+	defer OnAnyPanic(nil)
+
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: AfterThrowing, Handler: func(c *Context) error {
+		c.PanicValue = "sanitized"
+		return nil
+	}})
+
+	getUser := Wrap1RE(registry, "GetUser", explodingTarget)
+	if _, err := getUser(42); err == nil {
+		t.Fatal("expected the panic to surface as an error")
+	}
+
+	if funcKey != "GetUser" || value != "boom" {
+		t.Errorf("expected GetUser and the original value 'boom', got %s and %v", funcKey, value)
+	}
+	if !strings.Contains(string(stack), "explodingTarget") {
+		t.Errorf("expected the stack to show the panic site, got:\n%s", stack)
+	}
+}
+
+func TestOnAnyPanic_HookPanics(t *testing.T) {
+	OnAnyPanic(func(FuncKey, any, []byte) { panic("reporter down") })
+	defer OnAnyPanic(nil)
+
+	registry := NewRegistry()
+	registry.MustRegister("Fn")
+	registry.MustAddAdvice("Fn", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+
+	_, err := Wrap1RE(registry, "Fn", explodingTarget)(1)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the target's panic error despite the failing hook, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Package aspect - pattern provides advice applied to all functions matching a glob pattern
+package aspect
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// patternAdvice holds the advice attached to a glob pattern.
+type patternAdvice struct {
+	pattern string
+	chain   *AdviceChain
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AddPatternAdvice adds advice to every function whose key matches the glob pattern,
+// e.g. "UserService.*". The syntax is the one of path.Match.
+// Pattern advice is ordered between global and per-function advice at equal priority.
+// Returns error if the pattern is malformed or the registry is frozen (see FreezeAll).
+func (registry *Registry) AddPatternAdvice(pattern string, advice Advice) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern cannot be empty")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	for _, existing := range registry.patterns {
+		if existing.pattern == pattern {
+			return existing.chain.Add(advice)
+		}
+	}
+
+	if registry.frozen.Load() {
+		return fmt.Errorf("pattern '%s': %w", pattern, ErrChainFrozen)
+	}
+	chain := NewAdviceChain()
+	chain.Add(advice)
+	registry.patterns = append(registry.patterns, &patternAdvice{pattern: pattern, chain: chain})
+	registry.generation.Add(1)
+	return nil
+}
+
+// MustAddPatternAdvice adds pattern advice and panics on error.
+func (registry *Registry) MustAddPatternAdvice(pattern string, advice Advice) {
+	if err := registry.AddPatternAdvice(pattern, advice); err != nil {
+		panic(err)
+	}
+}
+
+// EffectiveAdviceCount returns the total number of advice that would run for funcKey,
+// including its own advice, global advice and advice of all matching patterns.
+func (registry *Registry) EffectiveAdviceCount(funcKey FuncKey) int {
+	count := registry.GetAdviceCount(funcKey)
+	for _, chain := range registry.sharedChains(funcKey) {
+		count += chain.Count()
+	}
+	return count
+}
+
+// FindFunctions returns the sorted keys of all registered functions matching the glob pattern,
+// using the same syntax as AddPatternAdvice. A malformed pattern matches nothing.
+func (registry *Registry) FindFunctions(pattern string) []FuncKey {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	matches := make([]FuncKey, 0)
+	for funcKey := range registry.entries {
+		if matched, _ := path.Match(pattern, string(funcKey)); matched {
+			matches = append(matches, funcKey)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i] < matches[j] })
+	return matches
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// matches reports whether funcKey matches the pattern.
+func (pa *patternAdvice) matches(funcKey FuncKey) bool {
+	matched, _ := path.Match(pa.pattern, string(funcKey))
+	return matched
+}
@@ -0,0 +1,117 @@
+// Package aspect - pattern_test validates pattern advice and effective advice counting
+package aspect
+
+import (
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_AddPatternAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("UserService.GetUser")
+	registry.MustRegister("OrderService.GetOrder")
+
+	var calls []FuncKey
+	registry.MustAddPatternAdvice("UserService.*", Advice{
+		Type: Before,
+		Handler: func(c *Context) error {
+			calls = append(calls, c.FunctionName)
+			return nil
+		},
+	})
+
+	Wrap0(registry, "UserService.GetUser", func() {})()
+	Wrap0(registry, "OrderService.GetOrder", func() {})()
+	Wrap0(registry, "UserService.DeleteUser", func() {})() // Not registered, still matched
+
+	expected := []FuncKey{"UserService.GetUser", "UserService.DeleteUser"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected pattern advice for %v, got %v", expected, calls)
+	}
+
+	if err := registry.AddPatternAdvice("[", Advice{Type: Before}); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+	if err := registry.AddPatternAdvice("", Advice{Type: Before}); err == nil {
+		t.Error("expected error for empty pattern")
+	}
+}
+
+func TestRegistry_PatternAdviceOrdering(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("UserService.GetUser")
+
+	var order []string
+	record := func(label string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, label)
+			return nil
+		}
+	}
+
+	registry.MustAddAdvice("UserService.GetUser", Advice{Type: Before, Handler: record("local-before")})
+	registry.MustAddAdvice("UserService.GetUser", Advice{Type: After, Handler: record("local-after")})
+	registry.MustAddPatternAdvice("UserService.*", Advice{Type: Before, Handler: record("pattern-before")})
+	registry.MustAddPatternAdvice("UserService.*", Advice{Type: After, Handler: record("pattern-after")})
+	registry.AddGlobalAdvice(Advice{Type: Before, Handler: record("global-before")})
+	registry.AddGlobalAdvice(Advice{Type: After, Handler: record("global-after")})
+
+	Wrap0(registry, "UserService.GetUser", func() {})()
+
+	expected := []string{
+		"global-before", "pattern-before", "local-before",
+		"local-after", "pattern-after", "global-after",
+	}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestRegistry_EffectiveAdviceCount(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("UserService.GetUser")
+
+	noop := func(c *Context) error { return nil }
+	registry.MustAddAdvice("UserService.GetUser", Advice{Type: Before, Handler: noop})
+	registry.MustAddAdvice("UserService.GetUser", Advice{Type: After, Handler: noop})
+	registry.MustAddPatternAdvice("UserService.*", Advice{Type: Around, Handler: noop})
+	registry.MustAddPatternAdvice("*.GetUser", Advice{Type: Before, Handler: noop})
+	registry.MustAddPatternAdvice("OrderService.*", Advice{Type: Before, Handler: noop})
+	registry.AddGlobalAdvice(Advice{Type: After, Handler: noop})
+
+	if count := registry.EffectiveAdviceCount("UserService.GetUser"); count != 5 {
+		t.Errorf("expected 5 effective advice (2 local + 2 pattern + 1 global), got %d", count)
+	}
+	if count := registry.EffectiveAdviceCount("OrderService.GetOrder"); count != 2 {
+		t.Errorf("expected 2 effective advice for an unregistered function, got %d", count)
+	}
+}
+
+func TestRegistry_FindFunctions(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []FuncKey{"UserService.Get", "OrderService.Create", "UserService.Delete", "Health"} {
+		registry.MustRegister(name)
+	}
+
+	tests := []struct {
+		pattern  string
+		expected []FuncKey
+	}{
+		{pattern: "*", expected: []FuncKey{"Health", "OrderService.Create", "UserService.Delete", "UserService.Get"}},
+		{pattern: "UserService.*", expected: []FuncKey{"UserService.Delete", "UserService.Get"}},
+		{pattern: "Health", expected: []FuncKey{"Health"}},
+		{pattern: "Missing", expected: []FuncKey{}},
+		{pattern: "[", expected: []FuncKey{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got := registry.FindFunctions(tt.pattern)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
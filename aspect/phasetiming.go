@@ -0,0 +1,54 @@
+// Package aspect - phasetiming records how long each phase of an invocation took
+package aspect
+
+import (
+	"maps"
+	"time"
+)
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetPhaseTiming enables recording the duration of each phase of advised invocations, exposed
+// by Context.PhaseTimings, e.g. for tracing advice attaching the breakdown to a span. It is off
+// by default; disabled, the engine does not read the clock per phase.
+func (registry *Registry) SetPhaseTiming(enabled bool) {
+	registry.phaseTiming.Store(enabled)
+}
+
+// PhaseTimings returns the time spent so far in each phase of the invocation, keyed by advice
+// type name ("Before", "Around", ...) and PhaseTarget for the target. Around excludes the time
+// of the target it proceeds to. Phases that did not run are absent; a phase read by its own
+// advice (e.g. After) is only recorded once it completed. It returns nil unless the registry
+// has SetPhaseTiming enabled.
+func (c *Context) PhaseTimings() map[string]time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return maps.Clone(c.phaseTimings)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// timingPhases reports whether the invocation records phase timings.
+func (c *Context) timingPhases() bool {
+	return c.phaseTimings != nil
+}
+
+// addPhaseTiming adds the time elapsed since start to the phase.
+func (c *Context) addPhaseTiming(phase string, start time.Time) time.Duration {
+	elapsed := now().Sub(start)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.phaseTimings[phase] += elapsed
+	return elapsed
+}
+
+// timedTarget returns targetFn recording its duration as the target phase.
+func (c *Context) timedTarget(targetFn func(*Context)) func(*Context) {
+	return func(c *Context) {
+		defer c.addPhaseTiming(PhaseTarget, now()) // Also records a panicking target
+		targetFn(c)
+	}
+}
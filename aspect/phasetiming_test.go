@@ -0,0 +1,54 @@
+// Package aspect - phasetiming_test validates the per-phase timing breakdown
+package aspect
+
+import (
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_SetPhaseTiming(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Slow")
+	registry.SetPhaseTiming(true)
+
+	noop := func(c *Context) error { return nil }
+	registry.MustAddAdvice("Slow", Advice{Type: Before, Handler: noop})
+	registry.MustAddAdvice("Slow", Advice{Type: Around, Handler: func(c *Context) error { return c.Proceed() }})
+	registry.MustAddAdvice("Slow", Advice{Type: AfterReturning, Handler: noop})
+	registry.MustAddAdvice("Slow", Advice{Type: After, Handler: noop})
+
+	const sleep = 20 * time.Millisecond
+	slow := Wrap1REx(registry, "Slow", func(d time.Duration) (int, error) {
+		time.Sleep(d)
+		return 1, nil
+	})
+	_, _, c := slow(sleep)
+	timings := c.PhaseTimings()
+
+	target := timings[PhaseTarget]
+	if target < sleep || target > 10*sleep {
+		t.Errorf("expected the target phase to match the sleep of %v, got %v", sleep, target)
+	}
+	for _, phase := range []string{"Before", "Around", "AfterReturning", "After"} {
+		duration, ok := timings[phase]
+		if !ok {
+			t.Errorf("expected a timing for phase %s, got %v", phase, timings)
+		}
+		if duration >= target {
+			t.Errorf("expected phase %s (%v) to take less than the target (%v)", phase, duration, target)
+		}
+	}
+}
+
+func TestRegistry_PhaseTimingDisabled(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Fn")
+	registry.MustAddAdvice("Fn", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+
+	_, _, c := Wrap1REx(registry, "Fn", func(x int) (int, error) { return x, nil })(1)
+	if timings := c.PhaseTimings(); timings != nil {
+		t.Errorf("expected no phase timings when disabled, got %v", timings)
+	}
+}
@@ -0,0 +1,72 @@
+// Package aspect - plan describes the advice an invocation would run, without executing it
+package aspect
+
+import (
+	"slices"
+	"sort"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// PhaseTarget is the phase of the plan step standing for the target function itself.
+const PhaseTarget = "Target"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// PlanStep is a single step of an execution plan.
+type PlanStep struct {
+	Phase       string // Phase is the advice type name (e.g. "Before") or PhaseTarget.
+	Name        string // Name is the advice name, or the handler's function name for unnamed advice.
+	Priority    int    // Priority is the advice priority (zero for the target step).
+	Conditional bool   // Conditional reports that the step may be skipped by a condition or a disabled group.
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// ExecutionPlan returns the steps an invocation of funcKey would run, in the order the engine
+// runs them: Before and Around advice by priority, the target, then AfterReturning, AfterThrowing
+// and After advice. AfterReturning runs only on success and AfterThrowing only on a panic, so at
+// most one of them applies to an invocation. Global and pattern advice are included.
+func (registry *Registry) ExecutionPlan(funcKey FuncKey) []PlanStep {
+	chain, err := registry.GetAdviceChain(funcKey)
+	if err != nil {
+		chain = NewAdviceChain() // Only global or pattern advice may apply
+	}
+
+	var plan []PlanStep
+	for _, adviceType := range phaseOrder {
+		if adviceType == AfterReturning {
+			plan = append(plan, PlanStep{Phase: PhaseTarget, Name: string(funcKey)})
+		}
+		for _, advice := range registry.orderedAdvice(funcKey, chain, adviceType) {
+			name := advice.Name
+			if name == "" {
+				name = handlerName(advice.Handler)
+			}
+			plan = append(plan, PlanStep{
+				Phase:       adviceTypeNames[adviceType],
+				Name:        name,
+				Priority:    advice.Priority,
+				Conditional: advice.Condition != nil || advice.Group != "",
+			})
+		}
+	}
+	return plan
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// orderedAdvice returns the effective advice of a type for funcKey in execution order.
+func (registry *Registry) orderedAdvice(funcKey FuncKey, chain *AdviceChain, adviceType AdviceType) []Advice {
+	adviceList := slices.Clone(registry.adviceFor(funcKey, chain, adviceType)) // Frozen lists are shared
+
+	overrides := registry.priorityOverrides(funcKey)
+	for i := range adviceList {
+		adviceList[i].Priority = effectivePriority(adviceList[i], overrides)
+	}
+
+	sort.SliceStable(adviceList, func(i, j int) bool {
+		return adviceList[i].Priority > adviceList[j].Priority
+	})
+	return adviceList
+}
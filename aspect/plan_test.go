@@ -0,0 +1,49 @@
+// Package aspect - plan_test validates the execution plan of a function
+package aspect
+
+import (
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_ExecutionPlan(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	noop := func(c *Context) error { return nil }
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Name: "audit", Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Name: "log", Priority: 10, Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: Around, Name: "cache", Priority: 50, Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Name: "auth", Priority: 100, Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: AfterReturning, Name: "metrics", Group: "metrics", Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:      AfterThrowing,
+		Name:      "alert",
+		Handler:   noop,
+		Condition: func(c *Context) bool { return true },
+	})
+	registry.AddGlobalAdvice(Advice{Type: Before, Handler: auditAdvice})
+
+	expected := []PlanStep{
+		{Phase: "Before", Name: "auth", Priority: 100},
+		{Phase: "Before", Name: "log", Priority: 10},
+		{Phase: "Before", Name: "github.com/seyallius/gosaidno/aspect.auditAdvice"},
+		{Phase: "Around", Name: "cache", Priority: 50},
+		{Phase: PhaseTarget, Name: "GetUser"},
+		{Phase: "AfterReturning", Name: "metrics", Conditional: true},
+		{Phase: "AfterThrowing", Name: "alert", Conditional: true},
+		{Phase: "After", Name: "audit"},
+	}
+
+	if plan := registry.ExecutionPlan("GetUser"); !reflect.DeepEqual(plan, expected) {
+		t.Errorf("expected plan:\n%+v\ngot:\n%+v", expected, plan)
+	}
+
+	// Unregistered functions still see the global advice
+	plan := registry.ExecutionPlan("Other")
+	if len(plan) != 2 || plan[1].Phase != PhaseTarget {
+		t.Errorf("expected global Before advice and the target, got %+v", plan)
+	}
+}
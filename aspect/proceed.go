@@ -0,0 +1,45 @@
+// Package aspect - proceed lets Around advice invoke the target itself
+package aspect
+
+import "errors"
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrProceedUnavailable is returned by Proceed and ProceedWith outside of Around advice.
+var ErrProceedUnavailable = errors.New("proceed is only available in Around advice")
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Proceed invokes the target from Around advice and returns its error. Around advice with a
+// lower priority runs nested within the call, so the target runs where the innermost advice
+// proceeds, and an error aborting nested advice is returned instead. Results, errors and panics
+// of the target are recorded in the context as usual, so the advice can inspect or replace them
+// before returning. Proceed may be called several times, e.g. to retry, each call running the
+// nested advice and the target again and overwriting the previous outcome. The target does not
+// run once advice skipped it (see Context.Skipped).
+func (c *Context) Proceed() error {
+	if c.proceed == nil {
+		return ErrProceedUnavailable
+	}
+
+	c.Error = nil
+	if err := c.proceed(); err != nil {
+		return err
+	}
+	return c.Error
+}
+
+// ProceedWith replaces the arguments and invokes the target with them, like Proceed.
+// The typed wrappers read the arguments back, so the target receives the new values;
+// arguments of an incompatible type are handled as for SetArg.
+func (c *Context) ProceedWith(args ...any) error {
+	if c.proceed == nil {
+		return ErrProceedUnavailable
+	}
+
+	c.Args = append([]any(nil), args...)
+	c.mu.Lock()
+	c.argsString = nil
+	c.mu.Unlock()
+	return c.Proceed()
+}
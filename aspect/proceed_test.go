@@ -0,0 +1,129 @@
+// Package aspect - proceed_test validates Around advice invoking the target itself
+package aspect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestContext_ProceedWith(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Square")
+
+	var afterReturning bool
+	registry.MustAddAdvice("Square", Advice{Type: Around, Handler: func(c *Context) error {
+		return c.ProceedWith(c.Args[0].(int) + 1)
+	}})
+	registry.MustAddAdvice("Square", Advice{Type: AfterReturning, Handler: func(c *Context) error {
+		afterReturning = true
+		return nil
+	}})
+
+	var runs int
+	square := Wrap1R(registry, "Square", func(x int) int {
+		runs++
+		return x * x
+	})
+
+	if got := square(2); got != 9 {
+		t.Errorf("expected the target to run with the incremented argument (9), got %d", got)
+	}
+	if runs != 1 {
+		t.Errorf("expected the target to run once, ran %d times", runs)
+	}
+	if !afterReturning {
+		t.Error("expected AfterReturning advice to run after proceeding")
+	}
+}
+
+func TestContext_Proceed_Retry(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Flaky")
+	registry.MustAddAdvice("Flaky", Advice{Type: Around, Handler: func(c *Context) error {
+		for attempt := 0; attempt < 3; attempt++ {
+			if c.Proceed() == nil {
+				return nil
+			}
+		}
+		return nil // Leave the last error in the context
+	}})
+
+	var runs int
+	flaky := Wrap0RE(registry, "Flaky", func() (string, error) {
+		runs++
+		if runs < 3 {
+			return "", errors.New("unavailable")
+		}
+		return "ok", nil
+	})
+
+	if got, err := flaky(); got != "ok" || err != nil {
+		t.Errorf("expected 'ok' on the third attempt, got %q, %v", got, err)
+	}
+	if runs != 3 {
+		t.Errorf("expected 3 attempts, got %d", runs)
+	}
+
+	if err := NewContext("test").Proceed(); !errors.Is(err, ErrProceedUnavailable) {
+		t.Errorf("expected ErrProceedUnavailable outside Around advice, got %v", err)
+	}
+}
+
+func TestContext_Proceed_Nested(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Save")
+
+	var order []string
+	proceedAs := func(name string) AdviceFunc {
+		return func(c *Context) error {
+			order = append(order, name+"-enter")
+			err := c.Proceed()
+			order = append(order, name+"-exit")
+			return err
+		}
+	}
+	registry.MustAddAdvice("Save", Advice{Type: Around, Priority: 10, Handler: proceedAs("inner")})
+	registry.MustAddAdvice("Save", Advice{Type: Around, Priority: 100, Handler: proceedAs("outer")})
+
+	save := Wrap0E(registry, "Save", func() error {
+		order = append(order, "target")
+		return nil
+	})
+	if err := save(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{"outer-enter", "inner-enter", "target", "inner-exit", "outer-exit"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected the target to run once within nested advice %v, got %v", expected, order)
+	}
+}
+
+func TestContext_Proceed_NestedError(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Save")
+
+	denied := errors.New("denied")
+	registry.MustAddAdvice("Save", Advice{Type: Around, Priority: 100, Handler: func(c *Context) error {
+		c.Proceed() // Ignores the error of the nested advice
+		return nil
+	}})
+	registry.MustAddAdvice("Save", Advice{Type: Around, Priority: 10, Handler: func(c *Context) error {
+		return denied
+	}})
+
+	var ran bool
+	save := Wrap0E(registry, "Save", func() error {
+		ran = true
+		return nil
+	})
+	if err := save(); !errors.Is(err, denied) {
+		t.Errorf("expected the nested advice's error to abort the call, got %v", err)
+	}
+	if ran {
+		t.Error("expected the target not to run")
+	}
+}
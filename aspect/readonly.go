@@ -0,0 +1,41 @@
+// Package aspect - readonly provides advice that observes invocations without modifying them
+package aspect
+
+import "context"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AddReadOnlyAdvice adds advice to the specified function like AddAdvice, guaranteeing that it
+// can only observe the invocation and perform side effects such as logging, e.g. for untrusted or
+// plugin-provided advice.
+//
+// Enforcement is by copy: the handler runs against a clone of the context (see Context.Clone)
+// that is discarded afterwards, so changes to results, the error, the arguments, Skipped or the
+// metadata never reach the invocation, and Proceed is unavailable. With SetExposeContext, FromContext
+// on the clone's context returns the clone, not the invocation's Context. The copy is shallow: values
+// reachable through pointers in the arguments or results are shared and not protected. Errors returned by the handler
+// and its panics do not affect the call either; they are reported to the OnAdviceError handler.
+// The clone costs a few allocations per call.
+// Returns error if the function is not registered.
+func (registry *Registry) AddReadOnlyAdvice(funcKey FuncKey, advice Advice) error {
+	handler := advice.Handler
+	adviceType := advice.Type
+
+	advice.Handler = func(c *Context) error {
+		view := c.Clone()
+		if _, exposed := FromContext(view.Context()); exposed {
+			view.ctx = context.WithValue(view.ctx, exposeKey{}, view)
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				registry.reportAdviceError(c, advicePanicError(adviceType, r))
+			}
+		}()
+
+		if err := handler(view); err != nil {
+			registry.reportAdviceError(c, &AdviceError{Phase: adviceType, Err: err})
+		}
+		return nil
+	}
+	return registry.AddAdvice(funcKey, advice)
+}
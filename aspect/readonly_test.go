@@ -0,0 +1,85 @@
+// Package aspect - readonly_test validates advice that cannot modify the invocation
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_AddReadOnlyAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var reported []error
+	registry.OnAdviceError(func(c *Context, err error) { reported = append(reported, err) })
+
+	var observed []any
+	err := registry.AddReadOnlyAdvice("GetUser", Advice{Type: Around, Handler: func(c *Context) error {
+		observed = append(observed, c.Arg(0))
+		c.SetResult(0, "intruder")
+		c.Skipped = true
+		c.Args[0] = -1
+		c.SetMetadataVal("tampered", true)
+		if err := c.Proceed(); !errors.Is(err, ErrProceedUnavailable) {
+			t.Errorf("expected Proceed to be unavailable, got %v", err)
+		}
+		return errors.New("plugin failed")
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = registry.AddReadOnlyAdvice("GetUser", Advice{Type: AfterReturning, Handler: func(c *Context) error {
+		c.Fail(errors.New("denied"))
+		panic("plugin crashed")
+	}})
+
+	var tampered bool
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Handler: func(c *Context) error {
+		_, tampered = c.GetMetadataVal("tampered")
+		return nil
+	}})
+
+	getUser := Wrap1RE(registry, "GetUser", func(id int) (string, error) { return "alice", nil })
+	user, err := getUser(42)
+
+	if user != "alice" || err != nil {
+		t.Errorf("expected the read-only advice not to affect the call, got %q, %v", user, err)
+	}
+	if len(observed) != 1 || observed[0] != 42 {
+		t.Errorf("expected the advice to observe the arguments, got %v", observed)
+	}
+	if tampered {
+		t.Error("expected metadata set by read-only advice not to reach the invocation")
+	}
+	if len(reported) != 2 || !errors.Is(reported[1], ErrAdvicePanic) {
+		t.Errorf("expected the error and the panic of the advice to be reported, got %v", reported)
+	}
+
+	if err := registry.AddReadOnlyAdvice("Unknown", Advice{Type: Before}); err == nil {
+		t.Error("expected error for an unregistered function")
+	}
+}
+
+func TestRegistry_AddReadOnlyAdvice_FromContext(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.SetExposeContext(true)
+
+	_ = registry.AddReadOnlyAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		exposed, ok := FromContext(c.Context())
+		if !ok || exposed != c {
+			t.Errorf("expected FromContext to return the read-only view, got %p (view %p)", exposed, c)
+		}
+		exposed.Skipped = true
+		exposed.SetResult(0, "intruder")
+		return nil
+	}})
+
+	getUser := Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id int) (string, error) { return "alice", nil })
+	if user, err := getUser(context.Background(), 42); user != "alice" || err != nil {
+		t.Errorf("expected the read-only advice not to affect the call, got %q, %v", user, err)
+	}
+}
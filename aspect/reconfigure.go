@@ -0,0 +1,50 @@
+// Package aspect - reconfigure replaces the advice of a function atomically
+package aspect
+
+import "fmt"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetChain replaces the advice chain of a function with chain, registering the function if
+// needed. Invocations starting afterwards use the new chain; invocations in progress complete
+// with the chain they started with. The chain must not be shared with another function.
+// Returns error if the function name is empty or chain is nil.
+func (registry *Registry) SetChain(funcKey FuncKey, chain *AdviceChain) error {
+	if chain == nil {
+		return fmt.Errorf("advice chain cannot be nil")
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if funcKey == "" {
+		return fmt.Errorf("function name cannot be empty")
+	}
+
+	registry.entries[funcKey] = chain
+	registry.bindFuncID(funcKey, chain)
+	registry.generation.Add(1)
+	return nil
+}
+
+// Reconfigure replaces the advice of a function with the advice declared by configure on a
+// fresh builder, for zero-gap configuration updates:
+//
+//	registry.Reconfigure("GetUser", func(b *aspect.FluentBuilder) {
+//		b.WithBefore(authenticate).WithAround(cacheFor(newTTL))
+//	})
+//
+// The new chain is built in isolation and swapped in with SetChain, so concurrent callers see
+// either the complete old or the complete new configuration, never a mix. Advice state (see
+// SetState) is not carried over. Global and pattern advice are unaffected.
+// Returns error if the function name is empty.
+func (registry *Registry) Reconfigure(funcKey FuncKey, configure func(builder *FluentBuilder)) error {
+	if funcKey == "" {
+		return fmt.Errorf("function name cannot be empty")
+	}
+
+	scratch := NewRegistry()
+	chain := scratch.RegisterOrGet(funcKey)
+	configure(ForWithRegistry(scratch, funcKey))
+	return registry.SetChain(funcKey, chain)
+}
@@ -0,0 +1,40 @@
+// Package aspect - reconfigure_test validates atomic replacement of the advice of a function
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_Reconfigure(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		c.SetMetadataVal("version", 1)
+		return nil
+	}})
+
+	err := registry.Reconfigure("GetUser", func(b *FluentBuilder) {
+		b.WithBefore(func(c *Context) error {
+			c.SetMetadataVal("version", 2)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count := registry.GetAdviceCount("GetUser"); count != 1 {
+		t.Errorf("expected the old advice to be replaced, got %d advice", count)
+	}
+	_, _, c := Wrap1REx(registry, "GetUser", func(id int) (int, error) { return id, nil })(1)
+	if version, _ := c.GetMetadataVal("version"); version != 2 {
+		t.Errorf("expected the new configuration, got version %v", version)
+	}
+
+	if err := registry.Reconfigure("", func(*FluentBuilder) {}); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := registry.SetChain("GetUser", nil); err == nil {
+		t.Error("expected error for a nil chain")
+	}
+}
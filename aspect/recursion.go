@@ -0,0 +1,88 @@
+// Package aspect - recursion protects against wrapped functions re-entering themselves through advice
+package aspect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+const (
+	RecursionAllow  RecursionPolicy = iota // RecursionAllow runs nested calls with full advice (default).
+	RecursionError                         // RecursionError fails nested calls with ErrRecursion.
+	RecursionBypass                        // RecursionBypass runs nested calls directly, without advice.
+)
+
+// ErrRecursion is returned by a nested call of a function whose recursion policy is RecursionError.
+var ErrRecursion = errors.New("recursive call through advice")
+
+// -------------------------------------------- Types --------------------------------------------
+
+// RecursionPolicy decides how a function handles being called again while it is executing,
+// e.g. by Around advice warming a cache through the wrapped function itself.
+type RecursionPolicy int
+
+// activeCallsKey is the context key of the functions executing in the current call stack.
+type activeCallsKey struct{}
+
+// activeCall is a node of the linked list of executing functions stored in the context.
+type activeCall struct {
+	funcKey FuncKey
+	parent  *activeCall
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetRecursionPolicy sets how nested calls of funcKey made while it executes are handled.
+//
+// Go has no goroutine-local storage, so executing functions are tracked through the context:
+// a nested call is detected when it receives the invocation's context, i.e. advice passes
+// c.Context() to a context-aware wrapper or Execute. Calls with an unrelated context are
+// not considered nested.
+func (registry *Registry) SetRecursionPolicy(funcKey FuncKey, policy RecursionPolicy) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.generation.Add(1)
+	if registry.recursion == nil {
+		registry.recursion = make(map[FuncKey]RecursionPolicy)
+	}
+	if policy == RecursionAllow {
+		delete(registry.recursion, funcKey)
+		return
+	}
+	registry.recursion[funcKey] = policy
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// recursionPolicy returns the recursion policy of funcKey.
+func (registry *Registry) recursionPolicy(funcKey FuncKey) RecursionPolicy {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return registry.recursion[funcKey]
+}
+
+// isActive reports whether funcKey is executing in the call stack tracked by ctx.
+func isActive(ctx context.Context, funcKey FuncKey) bool {
+	for call, _ := ctx.Value(activeCallsKey{}).(*activeCall); call != nil; call = call.parent {
+		if call.funcKey == funcKey {
+			return true
+		}
+	}
+	return false
+}
+
+// markActive returns a copy of ctx tracking funcKey as executing.
+func markActive(ctx context.Context, funcKey FuncKey) context.Context {
+	parent, _ := ctx.Value(activeCallsKey{}).(*activeCall)
+	return context.WithValue(ctx, activeCallsKey{}, &activeCall{funcKey: funcKey, parent: parent})
+}
+
+// recursionError returns the error of a rejected nested call.
+func recursionError(funcKey FuncKey) error {
+	return fmt.Errorf("%w: '%s'", ErrRecursion, funcKey)
+}
@@ -0,0 +1,83 @@
+// Package aspect - recursion_test validates recursion policies for self-calling advice
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_SetRecursionPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        RecursionPolicy
+		expectedErr   error
+		expectedCalls int
+	}{
+		{name: "Error", policy: RecursionError, expectedErr: ErrRecursion, expectedCalls: 1},
+		{name: "Bypass", policy: RecursionBypass, expectedCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("GetUser")
+			registry.SetRecursionPolicy("GetUser", tt.policy)
+
+			var getUser func(context.Context, int) (string, error)
+			var aroundRuns int
+			var nestedErr error
+			registry.MustAddAdvice("GetUser", Advice{Type: Around, Handler: func(c *Context) error {
+				aroundRuns++
+				if aroundRuns > 10 {
+					t.Fatal("infinite recursion through Around advice")
+				}
+				// Warm the cache through the wrapped function itself
+				_, nestedErr = getUser(c.Context(), c.Args[0].(int))
+				return nil
+			}})
+
+			var calls int
+			getUser = Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id int) (string, error) {
+				calls++
+				return "alice", nil
+			})
+
+			name, err := getUser(context.Background(), 1)
+			if err != nil || name != "alice" {
+				t.Fatalf("expected the outer call to succeed, got %q, %v", name, err)
+			}
+			if aroundRuns != 1 {
+				t.Errorf("expected Around advice to run once, ran %d times", aroundRuns)
+			}
+			if !errors.Is(nestedErr, tt.expectedErr) {
+				t.Errorf("expected nested error %v, got %v", tt.expectedErr, nestedErr)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d target calls, got %d", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRegistry_SetRecursionPolicy_SequentialCallsAreNotNested(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Ping")
+	registry.SetRecursionPolicy("Ping", RecursionError)
+	registry.MustAddAdvice("Ping", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+
+	ping := Wrap0ECtx(registry, "Ping", func(ctx context.Context) error { return nil })
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := ping(ctx); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+
+	registry.SetRecursionPolicy("Ping", RecursionAllow)
+	if registry.recursionPolicy("Ping") != RecursionAllow {
+		t.Error("expected the policy to be reset")
+	}
+}
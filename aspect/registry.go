@@ -2,12 +2,19 @@
 package aspect
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // -------------------------------------------- Global Variables --------------------------------------------
 
+// ErrSkipWithoutResult is reported when Around advice skips a result-returning function
+// without providing a usable result, so the caller receives the zero value.
+var ErrSkipWithoutResult = errors.New("target skipped without a result")
+
 var (
 	// defaultRegistry is the global default registry used by the fluent API
 	defaultRegistry *Registry
@@ -18,14 +25,46 @@ var (
 
 // Registry stores function references and their associated advice chains.
 type Registry struct {
-	mu      sync.RWMutex
-	entries map[FuncKey]*AdviceChain
+	mu       sync.RWMutex
+	entries  map[FuncKey]*AdviceChain
+	global   *AdviceChain
+	patterns []*patternAdvice
+	groups   adviceGroups
+
+	// generation is bumped on every change of the registered chains and per-function settings,
+	// invalidating memoized lookups.
+	generation   atomic.Uint64
+	frozen       atomic.Bool // frozen is set by FreezeAll, so chains created afterwards start frozen.
+	nextAdviceID atomic.Uint64
+
+	funcIDs     []funcIDSlot // funcIDs is indexed by FuncID and only grows (see RegisterID).
+	funcIDIndex map[FuncKey]FuncID
+
+	recursion      map[FuncKey]RecursionPolicy
+	overrides      map[FuncKey]map[string]int // overrides maps advice names to overridden priorities.
+	onAdviceError  func(c *Context, err error)
+	metadataStore  func() MetadataStore
+	strictArgs     atomic.Bool
+	exposeContext  atomic.Bool
+	phaseTiming    atomic.Bool
+	runtimeTrace   atomic.Bool
+	warnSkip       atomic.Bool
+	defaultTimeout atomic.Int64
+	maxMetadata    atomic.Int64
+
+	statsEnabled atomic.Bool
+	sizeStats    atomic.Bool
+	stats        sync.Map // FuncKey -> *funcStats
+
+	invocationLog atomic.Pointer[invocationLog]
+	maintenance   atomic.Pointer[maintenanceMode]
 }
 
 // NewRegistry creates a new empty registry.
 func NewRegistry() *Registry {
 	return &Registry{
 		entries: make(map[FuncKey]*AdviceChain),
+		global:  NewAdviceChain(),
 	}
 }
 
@@ -53,26 +92,21 @@ func (registry *Registry) Register(name FuncKey) error {
 		return fmt.Errorf("function '%s' is already registered", name)
 	}
 
-	registry.entries[name] = NewAdviceChain()
+	chain := registry.newChain()
+	registry.entries[name] = chain
+	registry.bindFuncID(name, chain)
+	registry.generation.Add(1)
 	return nil
 }
 
 // RegisterOrGet registers a function if not already registered, otherwise returns existing chain.
 // Always returns the advice chain and never errors.
+// It panics if name is empty; see GetOrCreateChain for a variant returning an error instead.
 func (registry *Registry) RegisterOrGet(name FuncKey) *AdviceChain {
-	registry.mu.Lock()
-	defer registry.mu.Unlock()
-
-	if name == "" {
-		panic("function name cannot be empty")
-	}
-
-	if chain, exists := registry.entries[name]; exists {
-		return chain
+	chain, err := registry.GetOrCreateChain(name)
+	if err != nil {
+		panic(err.Error())
 	}
-
-	chain := NewAdviceChain()
-	registry.entries[name] = chain
 	return chain
 }
 
@@ -85,7 +119,7 @@ func (registry *Registry) MustRegister(name FuncKey) {
 }
 
 // AddAdvice adds an advice to the specified function.
-// Returns error if the function is not registered.
+// Returns error if the function is not registered or its chain is frozen (see FreezeAll).
 func (registry *Registry) AddAdvice(funcKey FuncKey, advice Advice) error {
 	registry.mu.Lock()
 	defer registry.mu.Unlock()
@@ -99,7 +133,10 @@ func (registry *Registry) AddAdvice(funcKey FuncKey, advice Advice) error {
 		return fmt.Errorf("function '%s' is not registered", funcKey)
 	}
 
-	chain.Add(advice)
+	if err := chain.Add(advice); err != nil {
+		return fmt.Errorf("function '%s': %w", funcKey, err)
+	}
+	registry.generation.Add(1)
 	return nil
 }
 
@@ -129,6 +166,63 @@ func (registry *Registry) GetAdviceChain(funcKey FuncKey) (*AdviceChain, error)
 	return chain, nil
 }
 
+// GetOrCreateChain retrieves the advice chain for a function, registering the function first
+// if needed, so library code can obtain a chain without registering and getting in two steps.
+// Returns error if the function name is empty.
+func (registry *Registry) GetOrCreateChain(funcKey FuncKey) (*AdviceChain, error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if funcKey == "" {
+		return nil, fmt.Errorf("function name cannot be empty")
+	}
+
+	return registry.chainOrCreate(funcKey), nil
+}
+
+// ClearAdvice removes all advice of a function while keeping it registered.
+// Returns error if the function is not registered or its chain is frozen.
+func (registry *Registry) ClearAdvice(funcKey FuncKey) error {
+	chain, err := registry.GetAdviceChain(funcKey)
+	if err != nil {
+		return err
+	}
+	if err := chain.Clear(); err != nil {
+		return fmt.Errorf("function '%s': %w", funcKey, err)
+	}
+	registry.generation.Add(1)
+	return nil
+}
+
+// ClearAdviceType removes all advice of the given type from a function.
+// Returns error if the function is not registered or its chain is frozen.
+func (registry *Registry) ClearAdviceType(funcKey FuncKey, adviceType AdviceType) error {
+	chain, err := registry.GetAdviceChain(funcKey)
+	if err != nil {
+		return err
+	}
+	if err := chain.ClearType(adviceType); err != nil {
+		return fmt.Errorf("function '%s': %w", funcKey, err)
+	}
+	registry.generation.Add(1)
+	return nil
+}
+
+// SetParallelAfter makes the After and AfterReturning advice of a function run concurrently,
+// each on a copy of the context (see Context.Clone), and the call waits for all of them.
+// In parallel mode priority ordering is not honored and handlers must not mutate shared state;
+// changes they make to their context copy are discarded.
+// Returns error if the function is not registered.
+func (registry *Registry) SetParallelAfter(funcKey FuncKey, parallel bool) error {
+	chain, err := registry.GetAdviceChain(funcKey)
+	if err != nil {
+		return err
+	}
+
+	chain.parallelAfter.Store(parallel)
+	return nil
+}
+
 // IsRegistered checks if a function is registered.
 func (registry *Registry) IsRegistered(name FuncKey) bool {
 	registry.mu.RLock()
@@ -143,7 +237,10 @@ func (registry *Registry) IsRegistered(name FuncKey) bool {
 func (registry *Registry) Unregister(name FuncKey) {
 	registry.mu.Lock()
 	defer registry.mu.Unlock()
+
 	delete(registry.entries, name)
+	registry.bindFuncID(name, nil)
+	registry.generation.Add(1)
 }
 
 // ListRegistered returns all registered function names.
@@ -158,12 +255,49 @@ func (registry *Registry) ListRegistered() []FuncKey {
 	return names
 }
 
-// Clear removes all registered functions from the registry.
+// Clear removes all registered functions, global and pattern advice from the registry and
+// makes it mutable again (see FreezeAll).
 func (registry *Registry) Clear() {
 	registry.mu.Lock()
 	defer registry.mu.Unlock()
 
 	registry.entries = make(map[FuncKey]*AdviceChain)
+	for i := range registry.funcIDs {
+		registry.funcIDs[i].chain = nil // IDs stay issued, see RegisterID
+	}
+	registry.global = NewAdviceChain()
+	registry.patterns = nil
+	registry.frozen.Store(false)
+	registry.generation.Add(1)
+}
+
+// OnAdviceError sets a handler notified of errors detected by the execution engine
+// that would otherwise go unnoticed, such as invalid argument rewrites by advice.
+// Passing nil removes the handler.
+func (registry *Registry) OnAdviceError(handler func(c *Context, err error)) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.onAdviceError = handler
+}
+
+// SetWarnSkipNoResult enables reporting ErrSkipWithoutResult to the OnAdviceError handler
+// when Around advice skips a result-returning function without setting a result of the
+// expected type. The call itself is unaffected and still returns the zero value.
+func (registry *Registry) SetWarnSkipNoResult(warn bool) {
+	registry.warnSkip.Store(warn)
+}
+
+// SetDefaultTimeout sets a deadline applied to every context-aware wrapped call.
+// The advice and target see a context expiring after timeout, unless the incoming
+// context already has an earlier deadline. Zero or a negative value disables it.
+func (registry *Registry) SetDefaultTimeout(timeout time.Duration) {
+	registry.defaultTimeout.Store(int64(timeout))
+}
+
+// DefaultTimeout returns the default timeout of context-aware wrapped calls.
+func (registry *Registry) DefaultTimeout() time.Duration {
+	return time.Duration(registry.defaultTimeout.Load())
 }
 
 // Count returns the number of registered functions.
@@ -186,3 +320,47 @@ func (registry *Registry) GetAdviceCount(funcKey FuncKey) int {
 
 	return chain.Count()
 }
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// chainOrCreate returns the advice chain of name, registering it first if needed.
+// The caller must hold the registry lock.
+func (registry *Registry) chainOrCreate(name FuncKey) *AdviceChain {
+	if chain, exists := registry.entries[name]; exists {
+		return chain
+	}
+
+	chain := registry.newChain()
+	registry.entries[name] = chain
+	registry.bindFuncID(name, chain)
+	registry.generation.Add(1)
+	return chain
+}
+
+// newChain creates the chain of a newly registered function, frozen once the registry is.
+func (registry *Registry) newChain() *AdviceChain {
+	chain := NewAdviceChain()
+	if registry.frozen.Load() {
+		chain.Freeze()
+	}
+	return chain
+}
+
+// reportAdviceError forwards err to the OnAdviceError handler, if any.
+func (registry *Registry) reportAdviceError(c *Context, err error) {
+	registry.mu.RLock()
+	handler := registry.onAdviceError
+	registry.mu.RUnlock()
+
+	if handler != nil {
+		handler(c, err)
+	}
+}
+
+// warnSkipNoResult reports a skip without result if enabled. Safe to call with a nil registry.
+func (registry *Registry) warnSkipNoResult(c *Context) {
+	if registry == nil || !registry.warnSkip.Load() {
+		return
+	}
+	registry.reportAdviceError(c, fmt.Errorf("%w: '%s'", ErrSkipWithoutResult, c.FunctionName))
+}
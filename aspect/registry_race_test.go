@@ -106,3 +106,57 @@ func TestRegistryRace_RegisterAndUnregisterConcurrent(t *testing.T) {
 	}
 	// Note: Checking newly added functions might be racy itself, so we focus on ensuring no panic/crash.
 }
+
+func TestRegistryRace_ReconfigureWhileCalling(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Configured")
+
+	// Every configuration stamps the same generation in Before and After advice
+	configure := func(generation int) func(*FluentBuilder) {
+		return func(b *FluentBuilder) {
+			b.WithBefore(func(c *Context) error {
+				c.SetMetadataVal("before", generation)
+				return nil
+			}).WithAfter(func(c *Context) error {
+				c.SetMetadataVal("after", generation)
+				return nil
+			})
+		}
+	}
+	if err := registry.Reconfigure("Configured", configure(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := Wrap1REx(registry, "Configured", func(x int) (int, error) { return x, nil })
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, _, c := call(1)
+				before, _ := c.GetMetadataVal("before")
+				after, _ := c.GetMetadataVal("after")
+				if before == nil || before != after {
+					t.Errorf("expected a complete, consistent chain, got before=%v after=%v", before, after)
+					return
+				}
+			}
+		}()
+	}
+
+	for generation := 1; generation <= 200; generation++ {
+		if err := registry.Reconfigure("Configured", configure(generation)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -131,6 +131,31 @@ func TestRegistry_GetAdviceChain(t *testing.T) {
 	}
 }
 
+func TestRegistry_GetOrCreateChain(t *testing.T) {
+	registry := NewRegistry()
+
+	// Creates the chain of an unregistered function
+	chain, err := registry.GetOrCreateChain("TestFunc")
+	if err != nil || chain == nil {
+		t.Fatalf("expected a new chain, got %v, %v", chain, err)
+	}
+	if !registry.IsRegistered("TestFunc") {
+		t.Error("expected the function to be registered")
+	}
+
+	// Gets the existing chain
+	chain.Add(Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+	existing, err := registry.GetOrCreateChain("TestFunc")
+	if err != nil || existing != chain || existing.Count() != 1 {
+		t.Errorf("expected the existing chain, got %v, %v", existing, err)
+	}
+
+	// Errors instead of panicking on empty name
+	if _, err := registry.GetOrCreateChain(""); err == nil {
+		t.Error("expected error for empty name")
+	}
+}
+
 func TestRegistry_IsRegistered(t *testing.T) {
 	registry := NewRegistry()
 
@@ -354,3 +379,45 @@ func TestGlobalRegistry_Functions(t *testing.T) {
 	// Clean up
 	registry.Clear()
 }
+
+func TestRegistry_ClearAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("TestFunc")
+
+	var beforeCalls, afterCalls int
+	registry.MustAddAdvice("TestFunc", Advice{Type: Before, Handler: func(c *Context) error {
+		beforeCalls++
+		return nil
+	}})
+	registry.MustAddAdvice("TestFunc", Advice{Type: After, Handler: func(c *Context) error {
+		afterCalls++
+		return nil
+	}})
+
+	// Clearing only Before advice keeps After advice
+	if err := registry.ClearAdviceType("TestFunc", Before); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	Wrap0(registry, "TestFunc", func() {})()
+	if beforeCalls != 0 || afterCalls != 1 {
+		t.Fatalf("expected only After advice to run, got before=%d after=%d", beforeCalls, afterCalls)
+	}
+
+	// Clearing all advice keeps the function registered
+	if err := registry.ClearAdvice("TestFunc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !registry.IsRegistered("TestFunc") {
+		t.Fatal("function should still be registered")
+	}
+	if count := registry.GetAdviceCount("TestFunc"); count != 0 {
+		t.Fatalf("expected 0 advice, got %d", count)
+	}
+
+	if err := registry.ClearAdvice("NonExistent"); err == nil {
+		t.Error("expected error for unregistered function")
+	}
+	if err := registry.ClearAdviceType("NonExistent", After); err == nil {
+		t.Error("expected error for unregistered function")
+	}
+}
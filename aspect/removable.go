@@ -0,0 +1,112 @@
+// Package aspect - removable provides advice that can be removed individually after installation
+package aspect
+
+import "fmt"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// AdviceID identifies advice added with AddRemovableAdvice. The zero value identifies no advice.
+type AdviceID uint64
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AddRemovableAdvice adds advice to the specified function like AddAdvice and returns an ID
+// that removes it again with RemoveAdvice, e.g. for advice scoped to a test or a feature toggle.
+// Returns error if the function is not registered.
+func (registry *Registry) AddRemovableAdvice(funcKey FuncKey, advice Advice) (AdviceID, error) {
+	advice.id = AdviceID(registry.nextAdviceID.Add(1))
+	if err := registry.AddAdvice(funcKey, advice); err != nil {
+		return 0, err
+	}
+	return advice.id, nil
+}
+
+// RemoveAdvice removes the advice with the given ID from a function and reports whether it was
+// removed. Returns false if the function is not registered or has no such advice, and
+// ErrChainFrozen if its chain is frozen.
+func (registry *Registry) RemoveAdvice(funcKey FuncKey, id AdviceID) (bool, error) {
+	if id == 0 {
+		return false, nil
+	}
+
+	removed, err := registry.RemoveAdviceWhere(funcKey, func(advice Advice) bool { return advice.id == id })
+	return removed > 0, err
+}
+
+// RemoveAdviceWhere removes all advice of a function matching the predicate, e.g. all advice of
+// the "debug" group, and returns how many were removed. Returns 0 if the function is not
+// registered, and ErrChainFrozen if its chain is frozen.
+func (registry *Registry) RemoveAdviceWhere(funcKey FuncKey, pred func(Advice) bool) (int, error) {
+	chain, err := registry.GetAdviceChain(funcKey)
+	if err != nil {
+		return 0, nil
+	}
+
+	removed, err := chain.removeWhere(pred)
+	if err != nil {
+		return 0, fmt.Errorf("function '%s': %w", funcKey, err)
+	}
+	if removed > 0 {
+		registry.generation.Add(1)
+	}
+	return removed, nil
+}
+
+// RemoveTemporary removes all advice flagged Temporary from every function, the global advice
+// and the pattern advice, and returns how many were removed, e.g. to clean up between test
+// cases without clearing the permanent setup. Frozen chains are skipped and reported with
+// ErrChainFrozen after the mutable chains were cleaned up.
+func (registry *Registry) RemoveTemporary() (int, error) {
+	registry.mu.RLock()
+	chains := make([]*AdviceChain, 0, len(registry.entries)+len(registry.patterns)+1)
+	for _, chain := range registry.entries {
+		chains = append(chains, chain)
+	}
+	chains = append(chains, registry.global)
+	for _, pattern := range registry.patterns {
+		chains = append(chains, pattern.chain)
+	}
+	registry.mu.RUnlock()
+
+	removed := 0
+	var frozenErr error
+	for _, chain := range chains {
+		n, err := chain.removeWhere(func(advice Advice) bool { return advice.Temporary })
+		if err != nil {
+			frozenErr = err
+			continue
+		}
+		removed += n
+	}
+	if removed > 0 {
+		registry.generation.Add(1)
+	}
+	return removed, frozenErr
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// removeWhere removes all advice matching the predicate from all five advice lists under the
+// chain lock and returns how many were removed. Returns ErrChainFrozen if the chain is frozen.
+func (ac *AdviceChain) removeWhere(match func(Advice) bool) (int, error) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if ac.frozen.Load() {
+		return 0, ErrChainFrozen
+	}
+
+	removed := 0
+	for _, list := range []*[]Advice{&ac.before, &ac.after, &ac.around, &ac.afterReturning, &ac.afterThrowing} {
+		kept := make([]Advice, 0, len(*list))
+		for _, advice := range *list {
+			if match(advice) {
+				removed++
+				continue
+			}
+			kept = append(kept, advice)
+		}
+		*list = kept
+	}
+	return removed, nil
+}
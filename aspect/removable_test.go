@@ -0,0 +1,121 @@
+// Package aspect - removable_test validates removing individual advice by ID
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_RemoveAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var fired int
+	id, err := registry.AddRemovableAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		fired++
+		return nil
+	}})
+	if err != nil || id == 0 {
+		t.Fatalf("expected a valid ID, got %d, %v", id, err)
+	}
+
+	wrapped := Wrap0(registry, "GetUser", func() {})
+	wrapped()
+
+	if removed, err := registry.RemoveAdvice("GetUser", id); !removed || err != nil {
+		t.Fatalf("expected advice to be removed, got %v, %v", removed, err)
+	}
+	if removed, _ := registry.RemoveAdvice("GetUser", id); removed {
+		t.Error("expected a second removal to report false")
+	}
+
+	wrapped()
+	if fired != 1 {
+		t.Errorf("expected advice to stop firing after removal, fired %d times", fired)
+	}
+
+	if _, err := registry.AddRemovableAdvice("Missing", Advice{Type: Before}); err == nil {
+		t.Error("expected error for unregistered function")
+	}
+	if removed, _ := registry.RemoveAdvice("Missing", id); removed {
+		t.Error("expected removal from an unregistered function to report false")
+	}
+	if removed, _ := registry.RemoveAdvice("GetUser", 0); removed {
+		t.Error("expected removal of the zero ID to report false")
+	}
+}
+
+func TestRegistry_RemoveAdviceWhere(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var fired []string
+	record := func(label string) AdviceFunc {
+		return func(c *Context) error {
+			fired = append(fired, label)
+			return nil
+		}
+	}
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: record("before")})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Handler: record("after-1")})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Group: "debug", Handler: record("after-2")})
+
+	wrapped := Wrap0(registry, "GetUser", func() {})
+	wrapped() // Memoize the chain before removing
+
+	removed, err := registry.RemoveAdviceWhere("GetUser", func(advice Advice) bool { return advice.Type == After })
+	if removed != 2 || err != nil {
+		t.Errorf("expected 2 removed advice, got %d, %v", removed, err)
+	}
+
+	fired = nil
+	wrapped()
+	if len(fired) != 1 || fired[0] != "before" {
+		t.Errorf("expected only Before advice to remain, got %v", fired)
+	}
+
+	if got, _ := registry.RemoveAdviceWhere("GetUser", func(Advice) bool { return false }); got != 0 {
+		t.Errorf("expected nothing removed, got %d", got)
+	}
+	if got, _ := registry.RemoveAdviceWhere("Missing", func(Advice) bool { return true }); got != 0 {
+		t.Errorf("expected 0 for unregistered function, got %d", got)
+	}
+}
+
+func TestRegistry_RemoveTemporary(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustRegister("SaveUser")
+
+	var fired []string
+	record := func(name string) AdviceFunc {
+		return func(c *Context) error {
+			fired = append(fired, name)
+			return nil
+		}
+	}
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: record("permanent")})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: record("temporary"), Temporary: true})
+	registry.MustAddAdvice("SaveUser", Advice{Type: After, Handler: record("temporary-save"), Temporary: true})
+	registry.AddGlobalAdvice(Advice{Type: Before, Handler: record("temporary-global"), Temporary: true})
+	registry.MustAddPatternAdvice("*User", Advice{Type: Before, Handler: record("temporary-pattern"), Temporary: true})
+
+	getUser := Wrap0(registry, "GetUser", func() {})
+	getUser()
+	if len(fired) != 4 {
+		t.Fatalf("expected 4 advice to fire before cleanup, got %v", fired)
+	}
+
+	if removed, err := registry.RemoveTemporary(); removed != 4 || err != nil {
+		t.Errorf("expected 4 temporary advice to be removed, got %d, %v", removed, err)
+	}
+
+	fired = nil
+	getUser()
+	Wrap0(registry, "SaveUser", func() {})()
+	if len(fired) != 1 || fired[0] != "permanent" {
+		t.Errorf("expected only the permanent advice to remain, got %v", fired)
+	}
+	if removed, _ := registry.RemoveTemporary(); removed != 0 {
+		t.Errorf("expected nothing left to remove, got %d", removed)
+	}
+}
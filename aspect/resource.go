@@ -0,0 +1,63 @@
+// Package aspect - resource provides Around advice scoping a resource to the target's execution
+package aspect
+
+import "io"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AroundResource returns Around advice opening a resource such as a span or a database
+// transaction before the target runs and closing it once the target returned, even if it panicked:
+//
+//	aspect.AroundResource(
+//		func(c *aspect.Context) (io.Closer, error) { return db.Begin() },
+//		func(c *aspect.Context, tx io.Closer) {
+//			if c.Error != nil || c.HasPanic() {
+//				tx.(*Tx).Rollback()
+//				return
+//			}
+//			if err := tx.(*Tx).Commit(); err != nil {
+//				c.Fail(err)
+//			}
+//		},
+//	)
+//
+// An error of open aborts the call with an AdviceError and the target does not run. onClose sees
+// the outcome of the target: on a panic, PanicValue is already set and the panic propagates once
+// onClose returned. A nil onClose calls Close, failing an otherwise successful call with its error
+// (see Fail). The advice should have a high priority so that lower priority Around advice, e.g.
+// retries, runs nested within the resource's scope (see Proceed).
+func AroundResource(open func(*Context) (io.Closer, error), onClose func(*Context, io.Closer)) AdviceFunc {
+	if onClose == nil {
+		onClose = closeResource
+	}
+
+	return func(c *Context) error {
+		resource, err := open(c)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				c.PanicValue = r
+				onClose(c, resource)
+				panic(r) // The engine recovers it as for any panicking target
+			}
+			onClose(c, resource)
+		}()
+
+		if err := c.Proceed(); err == ErrProceedUnavailable {
+			return err
+		}
+		return nil // The target's error stays the call's error
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// closeResource is the default onClose of AroundResource.
+func closeResource(c *Context, resource io.Closer) {
+	if err := resource.Close(); err != nil && c.Error == nil && !c.HasPanic() {
+		c.Fail(err)
+	}
+}
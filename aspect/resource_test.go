@@ -0,0 +1,143 @@
+// Package aspect - resource_test validates Around advice scoping a resource to the target
+package aspect
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// testResource is an io.Closer recording whether it was closed.
+type testResource struct {
+	closed   bool
+	closeErr error
+}
+
+func (r *testResource) Close() error {
+	r.closed = true
+	return r.closeErr
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAroundResource_ClosesOnSuccessAndPanic(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Transfer")
+
+	var resource *testResource
+	var panicSeen any
+	registry.MustAddAdvice("Transfer", Advice{Type: Around, Handler: AroundResource(
+		func(c *Context) (io.Closer, error) {
+			resource = &testResource{}
+			return resource, nil
+		},
+		func(c *Context, closer io.Closer) {
+			panicSeen = c.PanicValue
+			_ = closer.Close()
+		},
+	)})
+
+	transfer := Wrap1E(registry, "Transfer", func(amount int) error {
+		if amount < 0 {
+			panic("negative amount")
+		}
+		return nil
+	})
+
+	if err := transfer(10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resource.closed || panicSeen != nil {
+		t.Errorf("expected the resource to be closed after success, closed=%v panic=%v", resource.closed, panicSeen)
+	}
+
+	err := transfer(-1)
+	if err == nil || !strings.Contains(err.Error(), "negative amount") || !resource.closed {
+		t.Errorf("expected the panic to surface and the resource to be closed, got %v (closed=%v)", err, resource.closed)
+	}
+	if panicSeen != "negative amount" {
+		t.Errorf("expected onClose to see the panic value, got %v", panicSeen)
+	}
+}
+
+func TestAroundResource_DefaultClose(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Save")
+
+	resource := &testResource{closeErr: errors.New("commit failed")}
+	registry.MustAddAdvice("Save", Advice{Type: Around, Handler: AroundResource(
+		func(c *Context) (io.Closer, error) { return resource, nil }, nil,
+	)})
+
+	var ran bool
+	if err := Wrap0E(registry, "Save", func() error { ran = true; return nil })(); err == nil || err.Error() != "commit failed" {
+		t.Errorf("expected the close error to fail the call, got %v", err)
+	}
+	if !ran || !resource.closed {
+		t.Errorf("expected the target to run and the resource to be closed, ran=%v closed=%v", ran, resource.closed)
+	}
+}
+
+func TestAroundResource_OpenError(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Save")
+	registry.MustAddAdvice("Save", Advice{Type: Around, Handler: AroundResource(
+		func(c *Context) (io.Closer, error) { return nil, errors.New("pool exhausted") }, nil,
+	)})
+
+	var ran bool
+	err := Wrap0E(registry, "Save", func() error { ran = true; return nil })()
+
+	var adviceErr *AdviceError
+	if !errors.As(err, &adviceErr) || adviceErr.Phase != Around || ran {
+		t.Errorf("expected an Around AdviceError without running the target, got %v (ran=%v)", err, ran)
+	}
+}
+
+func TestAroundResource_WithRetry(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Transfer")
+
+	var order []string
+	registry.MustAddAdvice("Transfer", Advice{Type: Around, Priority: 100, Handler: AroundResource(
+		func(c *Context) (io.Closer, error) {
+			order = append(order, "open")
+			return &testResource{}, nil
+		},
+		func(c *Context, closer io.Closer) { order = append(order, "close") },
+	)})
+	registry.MustAddAdvice("Transfer", Advice{Type: Around, Priority: 10, Handler: Retry(3, nil, func(c *Context) error {
+		c.SetRetriable(true)
+		return nil
+	})})
+
+	var runs int
+	transfer := Wrap0E(registry, "Transfer", func() error {
+		order = append(order, "target")
+		if runs++; runs < 3 {
+			return errors.New("conflict")
+		}
+		return nil
+	})
+	if err := transfer(); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+
+	expected := []string{"open", "target", "target", "target", "close"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected all retries within the resource's scope %v, got %v", expected, order)
+	}
+
+	// A successful call runs the target once
+	order, runs = nil, 2
+	if err := transfer(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"open", "target", "close"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected %v, got %v", expected, order)
+	}
+}
@@ -0,0 +1,28 @@
+// Package aspect - results provides typed access to the results of a context
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// ResultAs returns the result at index asserted to R.
+// ok is false if the result is missing or of another type.
+func ResultAs[R any](c *Context, index int) (R, bool) {
+	result, ok := c.GetResult(index).(R)
+	return result, ok
+}
+
+// Results2As returns the first two results asserted to R1 and R2.
+// ok is false if any of them is missing or of another type.
+func Results2As[R1, R2 any](c *Context) (R1, R2, bool) {
+	r1, ok1 := ResultAs[R1](c, 0)
+	r2, ok2 := ResultAs[R2](c, 1)
+	return r1, r2, ok1 && ok2
+}
+
+// Results3As returns the first three results asserted to R1, R2 and R3.
+// ok is false if any of them is missing or of another type.
+func Results3As[R1, R2, R3 any](c *Context) (R1, R2, R3, bool) {
+	r1, ok1 := ResultAs[R1](c, 0)
+	r2, ok2 := ResultAs[R2](c, 1)
+	r3, ok3 := ResultAs[R3](c, 2)
+	return r1, r2, r3, ok1 && ok2 && ok3
+}
@@ -0,0 +1,54 @@
+// Package aspect - results_test validates typed access to context results
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestResults2As(t *testing.T) {
+	c := NewContext("test")
+	c.SetResult(0, "alice")
+	c.SetResult(1, 42)
+
+	name, age, ok := Results2As[string, int](c)
+	if !ok || name != "alice" || age != 42 {
+		t.Errorf("expected (alice, 42, true), got (%q, %d, %v)", name, age, ok)
+	}
+
+	// Partially matching
+	if _, _, ok = Results2As[string, string](c); ok {
+		t.Error("expected ok=false for a mismatched second result")
+	}
+
+	// Short results
+	short := NewContext("test")
+	short.SetResult(0, "alice")
+	name, age, ok = Results2As[string, int](short)
+	if ok {
+		t.Error("expected ok=false for missing results")
+	}
+	if name != "alice" || age != 0 {
+		t.Errorf("expected matching positions to be filled, got (%q, %d)", name, age)
+	}
+}
+
+func TestResults3As(t *testing.T) {
+	c := NewContext("test")
+	c.SetResult(0, "alice")
+	c.SetResult(1, 42)
+	c.SetResult(2, true)
+
+	name, age, admin, ok := Results3As[string, int, bool](c)
+	if !ok || name != "alice" || age != 42 || !admin {
+		t.Errorf("expected (alice, 42, true, true), got (%q, %d, %v, %v)", name, age, admin, ok)
+	}
+
+	if _, _, _, ok = Results3As[string, int, string](c); ok {
+		t.Error("expected ok=false for a mismatched third result")
+	}
+
+	empty := NewContext("test")
+	if _, _, _, ok = Results3As[string, int, bool](empty); ok {
+		t.Error("expected ok=false for empty results")
+	}
+}
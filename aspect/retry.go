@@ -0,0 +1,66 @@
+// Package aspect - retry provides Around advice retrying failed invocations
+package aspect
+
+import "time"
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// RetriableMetadataKey is the metadata key backing Context.SetRetriable.
+const RetriableMetadataKey = "aspect.retriable"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetRetriable marks whether a failed invocation may be retried. It is a standard flag, so
+// independently written advice can cooperate on retry decisions without agreeing on a key,
+// e.g. Before advice marking idempotent operations retriable for the Retry advice.
+func (c *Context) SetRetriable(retriable bool) {
+	c.SetMetadataVal(RetriableMetadataKey, retriable)
+}
+
+// IsRetriable reports whether the invocation was marked retriable with SetRetriable.
+func (c *Context) IsRetriable() bool {
+	retriable, _ := c.GetMetadataVal(RetriableMetadataKey)
+	return retriable == true
+}
+
+// Retry returns Around advice invoking the target up to maxAttempts times while it fails and
+// the invocation is marked retriable (see SetRetriable), waiting backoff(attempt) between
+// attempts (e.g. ExponentialBackoff; nil retries immediately). The wait ends early when the
+// context is cancelled.
+//
+// After a failed attempt the classify handlers run, typically marking the invocation retriable
+// depending on c.Error. After advice cannot take this role: it runs once, after the whole
+// invocation including all attempts, so marking the invocation retriable there comes too late;
+// per-attempt decisions belong in classify, and Before advice can mark operations retriable
+// upfront. The last attempt's outcome is returned.
+//
+// Lower priority Around advice runs nested within each attempt, and higher priority Around
+// advice proceeding to Retry runs the attempts once (see Proceed).
+func Retry(maxAttempts int, backoff func(attempt int) time.Duration, classify ...AdviceFunc) AdviceFunc {
+	return func(c *Context) error {
+		for attempt := 0; ; attempt++ {
+			if c.Proceed() == nil || attempt+1 >= maxAttempts {
+				return nil
+			}
+
+			for _, handler := range classify {
+				if err := handler(c); err != nil {
+					return err
+				}
+			}
+			if !c.IsRetriable() {
+				return nil
+			}
+
+			if backoff != nil {
+				timer := time.NewTimer(backoff(attempt))
+				select {
+				case <-timer.C:
+				case <-c.Context().Done():
+					timer.Stop()
+					return nil // Keep the last attempt's error
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package aspect - retry_test validates the retriable flag and the Retry advice
+package aspect
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRetry_HonorsRetriableFlag(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	// Classification advice authored independently from the retry advice
+	markTransient := func(c *Context) error {
+		c.SetRetriable(errors.Is(c.Error, errTransient))
+		return nil
+	}
+
+	tests := []struct {
+		name         string
+		failures     []error
+		expectedRuns int
+		expectedErr  error
+	}{
+		{name: "transient errors are retried", failures: []error{errTransient, errTransient}, expectedRuns: 3},
+		{name: "fatal errors are not retried", failures: []error{errFatal}, expectedRuns: 1, expectedErr: errFatal},
+		{name: "attempts are bounded", failures: []error{errTransient, errTransient, errTransient, errTransient}, expectedRuns: 3, expectedErr: errTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister("Fetch")
+			registry.MustAddAdvice("Fetch", Advice{Type: Around, Handler: Retry(3, ExponentialBackoff(time.Millisecond, 5*time.Millisecond, 0), markTransient)})
+
+			var runs int
+			fetch := Wrap0RE(registry, "Fetch", func() (string, error) {
+				runs++
+				if runs <= len(tt.failures) {
+					return "", tt.failures[runs-1]
+				}
+				return "data", nil
+			})
+
+			_, err := fetch()
+			if runs != tt.expectedRuns {
+				t.Errorf("expected %d runs, got %d", tt.expectedRuns, runs)
+			}
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRetry_MarkedByBeforeAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Get")
+	registry.MustAddAdvice("Get", Advice{Type: Before, Handler: func(c *Context) error {
+		c.SetRetriable(true) // Reads are idempotent
+		return nil
+	}})
+	registry.MustAddAdvice("Get", Advice{Type: Around, Handler: Retry(2, nil)})
+
+	var runs int
+	get := Wrap0E(registry, "Get", func() error {
+		runs++
+		return errors.New("unavailable")
+	})
+
+	if err := get(); err == nil || runs != 2 {
+		t.Errorf("expected 2 runs ending with an error, got %d runs, %v", runs, err)
+	}
+	if NewContext("test").IsRetriable() {
+		t.Error("expected invocations not to be retriable by default")
+	}
+}
+
+func TestRetry_WithinAroundAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Fetch")
+
+	var order []string
+	registry.MustAddAdvice("Fetch", Advice{Type: Around, Priority: 100, Handler: func(c *Context) error {
+		order = append(order, "outer")
+		return c.Proceed()
+	}})
+	registry.MustAddAdvice("Fetch", Advice{Type: Around, Priority: 10, Handler: Retry(3, nil, func(c *Context) error {
+		c.SetRetriable(true)
+		return nil
+	})})
+	registry.MustAddAdvice("Fetch", Advice{Type: Around, Priority: 1, Handler: func(c *Context) error {
+		order = append(order, "inner")
+		return c.Proceed()
+	}})
+
+	var runs int
+	fetch := Wrap0RE(registry, "Fetch", func() (string, error) {
+		runs++
+		order = append(order, "target")
+		if runs < 2 {
+			return "", errors.New("transient")
+		}
+		return "data", nil
+	})
+
+	if got, err := fetch(); got != "data" || err != nil {
+		t.Fatalf("expected 'data' on the second attempt, got %q, %v", got, err)
+	}
+	expected := []string{"outer", "inner", "target", "inner", "target"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, order)
+			break
+		}
+	}
+
+	// A successful call runs the target once
+	order, runs = nil, 1
+	if _, err := fetch(); err != nil || runs != 2 {
+		t.Errorf("expected a single run, got %d runs, %v", runs-1, err)
+	}
+}
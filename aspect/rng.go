@@ -0,0 +1,64 @@
+// Package aspect - rng provides the random source of the built-in sampling and jitter helpers
+package aspect
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// lockedRNG serializes access to a *rand.Rand, which is not safe for concurrent use.
+type lockedRNG struct {
+	rng *rand.Rand
+	mu  sync.Mutex
+}
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// currentRNG is the random source used by the built-in helpers (nil uses the global source).
+var currentRNG atomic.Pointer[lockedRNG]
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetSampleRNG sets the random source used by the built-in sampling and jitter helpers, such as
+// Sample and ExponentialBackoff. Tests and deterministic canaries inject a seeded source so the
+// sampled invocations are reproducible:
+//
+//	aspect.SetSampleRNG(rand.New(rand.NewPCG(1, 2)))
+//
+// Draws are serialized with a mutex, so the source is safe to share across goroutines; the
+// order of draws, and thus the outcome, is only reproducible for invocations made in a fixed
+// order. Passing nil restores the global source, which is safe for concurrent use without
+// locking. The source is package-wide: tests replacing it must not run in parallel with others.
+func SetSampleRNG(r *rand.Rand) {
+	if r == nil {
+		currentRNG.Store(nil)
+		return
+	}
+	currentRNG.Store(&lockedRNG{rng: r})
+}
+
+// Sample returns an advice condition (see Advice.Condition) holding for about rate of the
+// invocations, e.g. to trace 1% of calls with rate 0.01. Rates of 0 or less never hold, 1 or
+// more always hold. The draws come from the source set with SetSampleRNG.
+func Sample(rate float64) func(c *Context) bool {
+	return func(c *Context) bool {
+		return randomFloat64() < rate
+	}
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// randomFloat64 returns a random value in [0, 1) from the configured source.
+func randomFloat64() float64 {
+	locked := currentRNG.Load()
+	if locked == nil {
+		return rand.Float64()
+	}
+
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float64()
+}
@@ -0,0 +1,92 @@
+// Package aspect - rng_test validates the seedable random source of the sampling helpers
+package aspect
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// sampledCalls runs 100 calls through Sample(0.3) advice seeded with seed and returns the
+// indices of the sampled calls.
+func sampledCalls(seed uint64) []int {
+	SetSampleRNG(rand.New(rand.NewPCG(seed, seed)))
+
+	registry := NewRegistry()
+	registry.MustRegister("Trace")
+
+	var sampled []int
+	registry.MustAddAdvice("Trace", Advice{Type: Before, Condition: Sample(0.3), Handler: func(c *Context) error {
+		sampled = append(sampled, c.Arg(0).(int))
+		return nil
+	}})
+
+	trace := Wrap1(registry, "Trace", func(i int) {})
+	for i := 0; i < 100; i++ {
+		trace(i)
+	}
+	return sampled
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestSetSampleRNG_Reproducible(t *testing.T) {
+	defer SetSampleRNG(nil)
+
+	first, second := sampledCalls(42), sampledCalls(42)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same seed to sample the same calls, got %v and %v", first, second)
+	}
+	if len(first) == 0 || len(first) == 100 {
+		t.Errorf("expected a fraction of the calls to be sampled, got %d", len(first))
+	}
+	if other := sampledCalls(7); reflect.DeepEqual(first, other) {
+		t.Errorf("expected another seed to sample other calls, got %v for both", other)
+	}
+}
+
+func TestSetSampleRNG_Jitter(t *testing.T) {
+	defer SetSampleRNG(nil)
+
+	delays := func() []time.Duration {
+		SetSampleRNG(rand.New(rand.NewPCG(1, 2)))
+		backoff := ExponentialBackoff(10*time.Millisecond, time.Second, 1)
+		return []time.Duration{backoff(0), backoff(1), backoff(2)}
+	}
+	if first, second := delays(), delays(); !reflect.DeepEqual(first, second) {
+		t.Errorf("expected reproducible jitter, got %v and %v", first, second)
+	}
+}
+
+func TestSetSampleRNG_Concurrent(t *testing.T) {
+	defer SetSampleRNG(nil)
+	SetSampleRNG(rand.New(rand.NewPCG(1, 2)))
+
+	sample := Sample(0.5)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				sample(nil)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSample_Bounds(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if Sample(0)(nil) {
+			t.Fatal("expected rate 0 never to sample")
+		}
+		if !Sample(1)(nil) {
+			t.Fatal("expected rate 1 always to sample")
+		}
+	}
+}
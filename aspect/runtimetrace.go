@@ -0,0 +1,30 @@
+// Package aspect - runtimetrace emits runtime/trace regions for the phases of an invocation
+package aspect
+
+import "runtime/trace"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetRuntimeTrace enables emitting a runtime/trace region for each phase of advised invocations
+// and for the target, named "<funcKey>.<phase>" (e.g. "GetUser.Before", "GetUser.Target"), so
+// advice overhead shows up in `go tool trace`. Around regions enclose the target region they
+// proceed to. Regions are only emitted while a trace is being collected (see trace.Start);
+// otherwise the cost is a single check per invocation. It is off by default.
+func (registry *Registry) SetRuntimeTrace(enabled bool) {
+	registry.runtimeTrace.Store(enabled)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// startRegion starts the runtime/trace region of a phase of the invocation.
+func (c *Context) startRegion(phase string) *trace.Region {
+	return trace.StartRegion(c.Context(), string(c.FunctionName)+"."+phase)
+}
+
+// tracedTarget returns targetFn running inside the target's runtime/trace region.
+func (c *Context) tracedTarget(targetFn func(*Context)) func(*Context) {
+	return func(c *Context) {
+		defer c.startRegion(PhaseTarget).End() // Also ends the region of a panicking target
+		targetFn(c)
+	}
+}
@@ -0,0 +1,49 @@
+// Package aspect - runtimetrace_test validates the runtime/trace regions of invocations
+package aspect
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// collectTrace runs fn while collecting a runtime trace and returns the trace.
+func collectTrace(t *testing.T, fn func()) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("runtime tracing unavailable: %v", err)
+	}
+	fn()
+	trace.Stop()
+	return buf.Bytes()
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_SetRuntimeTrace(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("TracedGetUser")
+	registry.MustAddAdvice("TracedGetUser", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+	registry.MustAddAdvice("TracedGetUser", Advice{Type: Around, Handler: func(c *Context) error {
+		c.Proceed()
+		return nil
+	}})
+	getUser := Wrap1R(registry, "TracedGetUser", func(id int) int { return id })
+
+	// Disabled by default
+	if data := collectTrace(t, func() { getUser(1) }); bytes.Contains(data, []byte("TracedGetUser.")) {
+		t.Error("expected no regions with runtime tracing disabled")
+	}
+
+	registry.SetRuntimeTrace(true)
+	data := collectTrace(t, func() { getUser(1) })
+	for _, region := range []string{"TracedGetUser.Before", "TracedGetUser.Around", "TracedGetUser.Target"} {
+		if !bytes.Contains(data, []byte(region)) {
+			t.Errorf("expected the trace to contain region %s", region)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Package aspect - shadow runs advice in shadow mode, recording its decisions without applying them
+package aspect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// ErrShadowDivergence is reported to the OnAdviceError handler when shadow advice would have
+// changed the invocation, e.g. skipped the target or returned an error.
+var ErrShadowDivergence = errors.New("shadow advice diverged")
+
+// -------------------------------------------- Types --------------------------------------------
+
+// ShadowDecision records what a shadow advice would have done to an invocation.
+type ShadowDecision struct {
+	Name    string     // Name is the advice name, or the handler's function name for unnamed advice.
+	Type    AdviceType // Type is the type of the advice.
+	Skipped bool       // Skipped reports that the advice would have skipped the target.
+	Results []any      // Results are the results the advice would have set, nil if it left them unchanged.
+	Err     error      // Err is the error the advice would have returned, or its recovered panic.
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Diverged reports whether the advice would have changed the invocation.
+func (decision ShadowDecision) Diverged() bool {
+	return decision.Skipped || decision.Results != nil || decision.Err != nil
+}
+
+// String describes the decision.
+func (decision ShadowDecision) String() string {
+	var effects []string
+	if decision.Skipped {
+		effects = append(effects, "skipped the target")
+	}
+	if decision.Results != nil {
+		effects = append(effects, fmt.Sprintf("set results %v", decision.Results))
+	}
+	if decision.Err != nil {
+		effects = append(effects, fmt.Sprintf("failed with %v", decision.Err))
+	}
+	if len(effects) == 0 {
+		effects = append(effects, "changed nothing")
+	}
+	return fmt.Sprintf("%s advice '%s' would have %s", adviceErrorPrefixes[decision.Type], decision.Name, strings.Join(effects, ", "))
+}
+
+// ShadowDecisions returns the decisions of the shadow advice that ran for the invocation
+// (see Advice.Shadow), in execution order.
+func (c *Context) ShadowDecisions() []ShadowDecision {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]ShadowDecision(nil), c.shadow...)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// runShadow runs shadow advice on a copy of the context and records its decision. Divergent
+// decisions are reported to the OnAdviceError handler wrapping ErrShadowDivergence. Nothing the
+// advice does is applied to the invocation; shadow Around advice cannot proceed.
+func (c *Context) runShadow(advice Advice) {
+	clone := c.Clone()
+
+	decision := ShadowDecision{Name: advice.Name, Type: advice.Type}
+	if decision.Name == "" {
+		decision.Name = handlerName(advice.Handler)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				decision.Err = advicePanicError(advice.Type, r)
+			}
+		}()
+		decision.Err = advice.Handler(clone)
+	}()
+
+	decision.Skipped = clone.Skipped && !c.Skipped
+	if !reflect.DeepEqual(clone.Results, c.Results) {
+		decision.Results = clone.Results
+	}
+
+	c.mu.Lock()
+	c.shadow = append(c.shadow, decision)
+	c.mu.Unlock()
+
+	if decision.Diverged() && c.registry != nil {
+		c.registry.reportAdviceError(c, fmt.Errorf("%w: %s", ErrShadowDivergence, decision))
+	}
+}
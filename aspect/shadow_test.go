@@ -0,0 +1,102 @@
+// Package aspect - shadow_test validates shadow advice recording decisions without applying them
+package aspect
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAdvice_ShadowAroundSkipIsRecordedNotHonored(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	var reported []error
+	registry.OnAdviceError(func(c *Context, err error) {
+		reported = append(reported, err)
+	})
+
+	var decisions []ShadowDecision
+	registry.MustAddAdvice("GetUser", Advice{
+		Type:   Around,
+		Name:   "cache",
+		Shadow: true,
+		Handler: func(c *Context) error {
+			c.SetResult(0, "cached-user")
+			c.Skipped = true
+			return nil
+		},
+	})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Handler: func(c *Context) error {
+		decisions = c.ShadowDecisions()
+		return nil
+	}})
+
+	var targetRan bool
+	getUser := Wrap1RE(registry, "GetUser", func(id int) (string, error) {
+		targetRan = true
+		return "db-user", nil
+	})
+
+	user, err := getUser(1)
+	if err != nil || user != "db-user" {
+		t.Errorf("expected the target's result, got %q, %v", user, err)
+	}
+	if !targetRan {
+		t.Error("expected the target to run despite the shadow skip")
+	}
+
+	if len(decisions) != 1 {
+		t.Fatalf("expected 1 shadow decision, got %d", len(decisions))
+	}
+	decision := decisions[0]
+	if decision.Name != "cache" || !decision.Skipped || !decision.Diverged() {
+		t.Errorf("expected a recorded skip of 'cache', got %+v", decision)
+	}
+	if len(decision.Results) != 1 || decision.Results[0] != "cached-user" {
+		t.Errorf("expected the would-be results to be recorded, got %v", decision.Results)
+	}
+
+	if len(reported) != 1 || !errors.Is(reported[0], ErrShadowDivergence) {
+		t.Errorf("expected the divergence to be reported, got %v", reported)
+	}
+}
+
+func TestAdvice_ShadowErrorAndPanicAreContained(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Save")
+
+	registry.MustAddAdvice("Save", Advice{Type: Before, Name: "deny", Shadow: true, Handler: func(c *Context) error {
+		return errors.New("denied")
+	}})
+	registry.MustAddAdvice("Save", Advice{Type: Before, Name: "explode", Shadow: true, Handler: func(c *Context) error {
+		panic("boom")
+	}})
+	registry.MustAddAdvice("Save", Advice{Type: Before, Name: "noop", Shadow: true, Handler: func(c *Context) error {
+		return nil
+	}})
+
+	var decisions []ShadowDecision
+	registry.MustAddAdvice("Save", Advice{Type: After, Handler: func(c *Context) error {
+		decisions = c.ShadowDecisions()
+		return nil
+	}})
+
+	if err := Wrap0E(registry, "Save", func() error { return nil })(); err != nil {
+		t.Fatalf("expected shadow advice not to fail the call, got %v", err)
+	}
+
+	if len(decisions) != 3 {
+		t.Fatalf("expected 3 shadow decisions, got %d", len(decisions))
+	}
+	if decisions[0].Err == nil || decisions[0].Err.Error() != "denied" {
+		t.Errorf("expected the would-be error to be recorded, got %v", decisions[0].Err)
+	}
+	if !errors.Is(decisions[1].Err, ErrAdvicePanic) {
+		t.Errorf("expected the panic to be recorded, got %v", decisions[1].Err)
+	}
+	if decisions[2].Diverged() {
+		t.Errorf("expected the no-op advice not to diverge, got %v", decisions[2])
+	}
+}
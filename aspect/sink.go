@@ -0,0 +1,87 @@
+// Package aspect - sink lets advice hand ancillary values back to the caller
+package aspect
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// ResultSink collects values produced by advice for the caller, such as the cache key used or
+// the backend chosen, alongside the normal results. It is safe for concurrent use; the methods
+// of a nil sink do nothing.
+type ResultSink struct {
+	values map[string]any
+	mu     sync.RWMutex
+}
+
+// sinkKey is the context key of the result sink of a registry's invocations.
+type sinkKey struct {
+	registry *Registry
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// WithResult returns a copy of ctx carrying a new result sink for invocations of the registry,
+// and the sink the caller reads once the call returned:
+//
+//	ctx, sink := registry.WithResult(ctx)
+//	user, err := GetUser(ctx, 42)
+//	backend, _ := sink.Get("backend") // Set by advice with c.Sink().Set("backend", ...)
+//
+// The sink is scoped by the context: use a fresh one per call. Nested calls of the registry
+// receiving the invocation's context write into the same sink. Only context-aware wrappers and
+// Execute see the sink.
+func (registry *Registry) WithResult(ctx context.Context) (context.Context, *ResultSink) {
+	sink := &ResultSink{values: make(map[string]any)}
+	return context.WithValue(ctx, sinkKey{registry: registry}, sink), sink
+}
+
+// Sink returns the result sink the caller attached with Registry.WithResult, or nil if there is
+// none; writing to a nil sink does nothing, so advice can write unconditionally.
+func (c *Context) Sink() *ResultSink {
+	if c.registry == nil {
+		return nil
+	}
+	sink, _ := c.Context().Value(sinkKey{registry: c.registry}).(*ResultSink)
+	return sink
+}
+
+// Set stores val under key.
+func (sink *ResultSink) Set(key string, val any) {
+	if sink == nil {
+		return
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	sink.values[key] = val
+}
+
+// Get returns the value stored under key.
+func (sink *ResultSink) Get(key string) (any, bool) {
+	if sink == nil {
+		return nil, false
+	}
+
+	sink.mu.RLock()
+	defer sink.mu.RUnlock()
+
+	val, exists := sink.values[key]
+	return val, exists
+}
+
+// Values returns a copy of all stored values.
+func (sink *ResultSink) Values() map[string]any {
+	if sink == nil {
+		return nil
+	}
+
+	sink.mu.RLock()
+	defer sink.mu.RUnlock()
+
+	return maps.Clone(sink.values)
+}
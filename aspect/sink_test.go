@@ -0,0 +1,52 @@
+// Package aspect - sink_test validates values handed back to the caller by advice
+package aspect
+
+import (
+	"context"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_WithResult(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		backend := "primary"
+		if c.Arg(0).(int)%2 == 0 {
+			backend = "replica"
+		}
+		c.Sink().Set("backend", backend)
+		return nil
+	}})
+
+	getUser := Wrap1RECtx(registry, "GetUser", func(ctx context.Context, id int) (string, error) {
+		return "alice", nil
+	})
+
+	ctx, sink := registry.WithResult(context.Background())
+	if user, err := getUser(ctx, 42); user != "alice" || err != nil {
+		t.Fatalf("expected 'alice', got %q, %v", user, err)
+	}
+	if backend, ok := sink.Get("backend"); !ok || backend != "replica" {
+		t.Errorf("expected the advice to report the replica backend, got %v (found=%v)", backend, ok)
+	}
+	if values := sink.Values(); len(values) != 1 {
+		t.Errorf("expected one value, got %v", values)
+	}
+
+	// Calls without a sink are unaffected
+	if _, err := getUser(context.Background(), 1); err != nil {
+		t.Errorf("unexpected error without a sink: %v", err)
+	}
+
+	// A sink of another registry is not written
+	otherCtx, other := NewRegistry().WithResult(ctx)
+	_, _ = getUser(otherCtx, 1)
+	if _, ok := other.Get("backend"); ok {
+		t.Error("expected the sink of another registry to stay empty")
+	}
+	if backend, _ := sink.Get("backend"); backend != "primary" {
+		t.Errorf("expected the registry's own sink to be written, got %v", backend)
+	}
+}
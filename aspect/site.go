@@ -0,0 +1,99 @@
+// Package aspect - site holds the per-wrapper state shared by all invocations of a wrapped function
+package aspect
+
+import "sync/atomic"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// wrapSite is created once per Wrap call and shared by every invocation of the returned closure.
+// It memoizes the advice chain lookup so the steady state avoids the registry lock and map lookup.
+type wrapSite struct {
+	registry   *Registry
+	funcKey    FuncKey
+	registries []*Registry // registries, when set, are merged instead of using registry alone.
+	id         FuncID      // id identifies the function when byID is set (see RegisterID).
+	byID       bool
+	options    wrapOptions // options are the per-site options of the *Opts wrappers.
+	cached     atomic.Pointer[cachedChain]
+}
+
+// cachedChain is the result of the lookups of a wrapped function at a given registry generation,
+// so invocations read everything they need from the registry without taking its lock.
+type cachedChain struct {
+	generation uint64
+	chain      *AdviceChain
+	err        error
+	shared     []*AdviceChain       // shared are the global and matching pattern chains, possibly empty.
+	recursion  RecursionPolicy      // recursion is the registry's recursion policy of the function.
+	overrides  map[string]int       // overrides are the priority overrides of the function, never mutated.
+	newStore   func() MetadataStore // newStore is the registry's metadata store factory, if any.
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// newWrapSite creates the shared state of a wrapped function.
+func newWrapSite(registry *Registry, funcKey FuncKey) *wrapSite {
+	return &wrapSite{registry: registry, funcKey: funcKey}
+}
+
+// chain returns the advice chain of the wrapped function (see lookup).
+func (site *wrapSite) chain() (*AdviceChain, error) {
+	cached := site.lookup()
+	return cached.chain, cached.err
+}
+
+// lookup returns the advice chain and settings of the wrapped function, looking them up again
+// only when the registry generation changed since the last lookup.
+func (site *wrapSite) lookup() *cachedChain {
+	registry := site.registry
+	if len(site.registries) > 0 {
+		chain, err := mergeChains(site.registries, site.funcKey)
+		return registry.resolve(site.funcKey, &cachedChain{chain: chain, err: err})
+	}
+
+	generation := registry.generation.Load()
+	if cached := site.cached.Load(); cached != nil && cached.generation == generation {
+		return cached
+	}
+
+	// A concurrent change bumps the generation again, so a stale entry is never reused.
+	cached := &cachedChain{generation: generation}
+	if site.byID {
+		cached.chain, cached.err = registry.chainByID(site.id)
+	} else {
+		cached.chain, cached.err = registry.GetAdviceChain(site.funcKey)
+	}
+	cached.shared = registry.matchingChains(site.funcKey)
+	registry.resolve(site.funcKey, cached)
+	site.cached.Store(cached)
+	return cached
+}
+
+// recursionPolicy returns the recursion policy of the wrapped function, preferring the site's own.
+func (site *wrapSite) recursionPolicy(cached *cachedChain) RecursionPolicy {
+	if site.options.recursion != nil {
+		return *site.options.recursion
+	}
+	return cached.recursion
+}
+
+// resolve fills in the per-function settings of a lookup and returns it.
+func (registry *Registry) resolve(funcKey FuncKey, cached *cachedChain) *cachedChain {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	cached.recursion = registry.recursion[funcKey]
+	cached.overrides = registry.overrides[funcKey]
+	cached.newStore = registry.metadataStore
+	return cached
+}
+
+// hasSharedAdvice reports whether any of the lookup's shared chains holds advice.
+func (cached *cachedChain) hasSharedAdvice() bool {
+	for _, chain := range cached.shared {
+		if chain.Count() > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+// Package aspect - site_test validates memoized chain lookup of wrapped functions
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWrapSite_CacheInvalidation(t *testing.T) {
+	registry := NewRegistry()
+
+	var beforeCalls int
+	wrapped := Wrap0(registry, "LateRegistered", func() {})
+
+	// Not registered yet: the failed lookup is memoized too
+	wrapped()
+	wrapped()
+
+	registry.MustRegister("LateRegistered")
+	registry.MustAddAdvice("LateRegistered", Advice{Type: Before, Handler: func(c *Context) error {
+		beforeCalls++
+		return nil
+	}})
+
+	wrapped()
+	if beforeCalls != 1 {
+		t.Fatalf("expected newly added advice to be picked up, got %d calls", beforeCalls)
+	}
+
+	// Re-registering swaps the chain: the wrapper must follow the new one
+	registry.Unregister("LateRegistered")
+	wrapped()
+	if beforeCalls != 1 {
+		t.Fatalf("expected no advice after unregistering, got %d calls", beforeCalls)
+	}
+
+	registry.MustRegister("LateRegistered")
+	registry.MustAddAdvice("LateRegistered", Advice{Type: Before, Handler: func(c *Context) error {
+		beforeCalls += 10
+		return nil
+	}})
+	wrapped()
+	if beforeCalls != 11 {
+		t.Fatalf("expected advice of the new chain to run, got %d calls", beforeCalls)
+	}
+}
+
+func TestWrapSite_ReusesLookup(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Cached")
+
+	site := newWrapSite(registry, "Cached")
+	first, err := site.chain()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cached := site.cached.Load()
+
+	second, _ := site.chain()
+	if first != second || site.cached.Load() != cached {
+		t.Error("expected the memoized entry to be reused while the generation is unchanged")
+	}
+}
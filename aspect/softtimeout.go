@@ -0,0 +1,48 @@
+// Package aspect - softtimeout provides observational timeouts for any wrapped function
+package aspect
+
+import (
+	"math"
+	"time"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// softTimeoutStartKey is the metadata key holding the start time recorded by SoftTimeout.
+const softTimeoutStartKey = "aspect.softTimeoutStart"
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SoftTimeout returns a Before and After advice pair calling onExceed when an invocation takes
+// longer than budget. Nothing is cancelled: it only observes, e.g. to log slow calls, so it
+// works for wrappers without a context.Context too. The Before advice runs first and the After
+// advice last, so the measured duration covers the whole invocation, advice included.
+//
+//	for _, advice := range aspect.SoftTimeout(200*time.Millisecond, logSlowCall) {
+//		registry.MustAddAdvice("GetUser", advice)
+//	}
+func SoftTimeout(budget time.Duration, onExceed func(*Context)) []Advice {
+	return []Advice{
+		{
+			Type:     Before,
+			Priority: math.MaxInt,
+			Name:     "softTimeout",
+			Handler: func(c *Context) error {
+				c.SetMetadataVal(softTimeoutStartKey, now())
+				return nil
+			},
+		},
+		{
+			Type:     After,
+			Priority: math.MinInt,
+			Name:     "softTimeout",
+			Handler: func(c *Context) error {
+				val, _ := c.GetMetadataVal(softTimeoutStartKey)
+				if start, ok := val.(time.Time); ok && now().Sub(start) > budget {
+					onExceed(c)
+				}
+				return nil
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+// Package aspect - softtimeout_test validates observational timeouts
+package aspect
+
+import (
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestSoftTimeout(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Report")
+
+	var exceeded []FuncKey
+	for _, advice := range SoftTimeout(5*time.Millisecond, func(c *Context) {
+		exceeded = append(exceeded, c.FunctionName)
+	}) {
+		registry.MustAddAdvice("Report", advice)
+	}
+
+	delay := 20 * time.Millisecond
+	report := Wrap0R(registry, "Report", func() string {
+		time.Sleep(delay)
+		return "done"
+	})
+
+	if got := report(); got != "done" {
+		t.Errorf("expected the slow call to complete, got %q", got)
+	}
+	if len(exceeded) != 1 || exceeded[0] != "Report" {
+		t.Fatalf("expected onExceed for the slow call, got %v", exceeded)
+	}
+
+	delay = 0
+	report()
+	if len(exceeded) != 1 {
+		t.Errorf("expected no onExceed for a fast call, got %d calls", len(exceeded))
+	}
+}
@@ -0,0 +1,50 @@
+// Package aspect - stack provides debug advice capturing the call stack of an invocation
+package aspect
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+)
+
+// -------------------------------------------- Constants & Variables --------------------------------------------
+
+// StackMetadataKey is the metadata key under which CaptureStack stores the captured stack.
+const StackMetadataKey = "aspect.stack"
+
+// maxStackDepth bounds the number of frames recorded by CaptureStack.
+const maxStackDepth = 64
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// CaptureStack returns advice recording the calling goroutine's stack into the context metadata,
+// readable later with Context.Stack (e.g. from AfterThrowing advice to log where a call originated).
+// skip is the number of additional innermost frames to omit, on top of the capture machinery itself.
+//
+// Capturing a stack is expensive: install it in diagnostic builds only, typically as Before advice.
+func CaptureStack(skip int) AdviceFunc {
+	return func(c *Context) error {
+		pcs := make([]uintptr, maxStackDepth)
+		n := runtime.Callers(2+skip, pcs) // Skip runtime.Callers and this handler
+
+		var buf bytes.Buffer
+		frames := runtime.CallersFrames(pcs[:n])
+		for {
+			frame, more := frames.Next()
+			fmt.Fprintf(&buf, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			if !more {
+				break
+			}
+		}
+
+		c.SetMetadataVal(StackMetadataKey, buf.Bytes())
+		return nil
+	}
+}
+
+// Stack returns the stack recorded by CaptureStack, or nil if none was captured.
+func (c *Context) Stack() []byte {
+	stack, _ := c.GetMetadataVal(StackMetadataKey)
+	trace, _ := stack.([]byte)
+	return trace
+}
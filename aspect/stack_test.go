@@ -0,0 +1,34 @@
+// Package aspect - stack_test validates stack capturing advice
+package aspect
+
+import (
+	"bytes"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestCaptureStack(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Risky")
+	registry.MustAddAdvice("Risky", Advice{Type: Before, Handler: CaptureStack(0)})
+
+	var stack []byte
+	registry.MustAddAdvice("Risky", Advice{Type: AfterThrowing, Handler: func(c *Context) error {
+		stack = c.Stack()
+		return nil
+	}})
+
+	Wrap0(registry, "Risky", func() { panic("boom") })()
+
+	if !bytes.Contains(stack, []byte("TestCaptureStack")) {
+		t.Errorf("expected captured stack to mention the test function, got:\n%s", stack)
+	}
+	if bytes.Contains(stack, []byte("runtime.Callers")) {
+		t.Errorf("expected capture machinery to be skipped, got:\n%s", stack)
+	}
+
+	if NewContext("empty").Stack() != nil {
+		t.Error("expected nil stack when none was captured")
+	}
+}
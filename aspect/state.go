@@ -0,0 +1,30 @@
+// Package aspect - state lets stateful advice keep observable state on its advice chain
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// SetState stores advice state under key on the chain. Stateful advice helpers (counters,
+// circuit breakers) keep their state here instead of in closures, so it can be inspected or
+// reset from outside, e.g. by an admin endpoint going through Registry.GetAdviceChain.
+// State is independent of the advice: Clear and ClearType keep it. Safe for concurrent use.
+func (ac *AdviceChain) SetState(key string, val any) {
+	ac.state.Store(key, val)
+}
+
+// GetState returns the advice state stored under key on the chain.
+func (ac *AdviceChain) GetState(key string) (any, bool) {
+	return ac.state.Load(key)
+}
+
+// DeleteState removes the advice state stored under key on the chain.
+func (ac *AdviceChain) DeleteState(key string) {
+	ac.state.Delete(key)
+}
+
+// StateAs returns the advice state stored under key on the chain asserted to T.
+// ok is false if the state is missing or of another type.
+func StateAs[T any](chain *AdviceChain, key string) (T, bool) {
+	val, _ := chain.GetState(key)
+	state, ok := val.(T)
+	return state, ok
+}
@@ -0,0 +1,80 @@
+// Package aspect - state_test validates advice state stored on advice chains
+package aspect
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAdviceChain_State(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Charge")
+
+	chain, _ := registry.GetAdviceChain("Charge")
+	breaker := &testBreaker{}
+	chain.SetState("breaker", breaker)
+
+	registry.MustAddAdvice("Charge", Advice{Type: AfterReturning, Handler: func(c *Context) error {
+		state, _ := registry.GetAdviceChain("Charge")
+		if b, ok := StateAs[*testBreaker](state, "breaker"); ok {
+			b.successes.Add(1)
+		}
+		return nil
+	}})
+
+	charge := Wrap0E(registry, "Charge", func() error { return nil })
+	_ = charge()
+	_ = charge()
+
+	// An admin endpoint reads the typed state through the registry
+	observed, _ := registry.GetAdviceChain("Charge")
+	b, ok := StateAs[*testBreaker](observed, "breaker")
+	if !ok || b.successes.Load() != 2 {
+		t.Errorf("expected the breaker to count 2 successes, got %v", b)
+	}
+
+	if _, ok := StateAs[string](observed, "breaker"); ok {
+		t.Error("expected a type mismatch to report false")
+	}
+	if _, ok := observed.GetState("missing"); ok {
+		t.Error("expected missing state to report false")
+	}
+
+	chain.Clear()
+	if _, ok := chain.GetState("breaker"); !ok {
+		t.Error("expected state to survive clearing the advice")
+	}
+	chain.DeleteState("breaker")
+	if _, ok := chain.GetState("breaker"); ok {
+		t.Error("expected deleted state to be gone")
+	}
+}
+
+func TestAdviceChain_StateConcurrent(t *testing.T) {
+	chain := NewAdviceChain()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chain.SetState("last", i)
+			_, _ = StateAs[int](chain, "last")
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := StateAs[int](chain, "last"); !ok {
+		t.Error("expected state to be set")
+	}
+}
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// testBreaker is a minimal stateful advice state.
+type testBreaker struct {
+	successes atomic.Int64
+}
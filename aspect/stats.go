@@ -0,0 +1,244 @@
+// Package aspect - stats collects per-function invocation statistics
+package aspect
+
+import (
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// FunctionStats is a snapshot of the invocation statistics of a function.
+type FunctionStats struct {
+	Calls         uint64        // Calls is the number of completed invocations.
+	Errors        uint64        // Errors is the number of invocations that returned an error without panicking.
+	Panics        uint64        // Panics is the number of invocations that panicked.
+	TotalDuration time.Duration // TotalDuration is the summed wall time of all invocations, advice included.
+	MaxDuration   time.Duration // MaxDuration is the longest invocation.
+	TotalArgs     uint64        // TotalArgs is the summed number of arguments of all invocations.
+	ArgBytes      uint64        // ArgBytes is the summed estimated size of the arguments (see EnableSizeStats).
+	ResultBytes   uint64        // ResultBytes is the summed estimated size of the results (see EnableSizeStats).
+
+	// AdviceDuration and TargetDuration split the time of invocations with phase timing
+	// (see SetPhaseTiming) into the time spent in advice and in the target.
+	AdviceDuration time.Duration
+	TargetDuration time.Duration
+}
+
+// funcStats holds the live counters of a function.
+type funcStats struct {
+	calls       atomic.Uint64
+	errors      atomic.Uint64
+	panics      atomic.Uint64
+	totalNanos  atomic.Int64
+	maxNanos    atomic.Int64
+	args        atomic.Uint64
+	argBytes    atomic.Uint64
+	resultBytes atomic.Uint64
+	adviceNanos atomic.Int64
+	targetNanos atomic.Int64
+	lastPanic   atomic.Pointer[panicRecord]
+}
+
+// panicRecord is a recovered panic and the time it was recovered.
+type panicRecord struct {
+	value any
+	when  time.Time
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// EnableStats turns the collection of per-function invocation statistics on or off.
+// Collection is off by default and costs a clock read and a few atomic updates per call.
+func (registry *Registry) EnableStats(enabled bool) {
+	registry.statsEnabled.Store(enabled)
+}
+
+// EnableSizeStats turns the estimation of argument and result sizes on or off, e.g. to
+// correlate GC pressure with the wrapped functions passing large values around. It only applies
+// while stats are enabled (see EnableStats) and costs a shallow walk of every argument and result
+// per call. Sizes are estimates: the size of the value itself plus the backing data of strings,
+// slices and maps and the values pointers point to, one level deep.
+func (registry *Registry) EnableSizeStats(enabled bool) {
+	registry.sizeStats.Store(enabled)
+}
+
+// StatsEnabled reports whether invocation statistics are collected.
+func (registry *Registry) StatsEnabled() bool {
+	return registry.statsEnabled.Load()
+}
+
+// Stats returns the invocation statistics of funcKey, or zero stats if it was never invoked
+// while collection was enabled.
+func (registry *Registry) Stats(funcKey FuncKey) FunctionStats {
+	val, exists := registry.stats.Load(funcKey)
+	if !exists {
+		return FunctionStats{}
+	}
+	return val.(*funcStats).snapshot()
+}
+
+// LastPanic returns the value of the most recent panic recovered from funcKey and when it
+// happened. ok is false if the function has not panicked while collection was enabled.
+func (registry *Registry) LastPanic(funcKey FuncKey) (value any, when time.Time, ok bool) {
+	val, exists := registry.stats.Load(funcKey)
+	if !exists {
+		return nil, time.Time{}, false
+	}
+	record := val.(*funcStats).lastPanic.Load()
+	if record == nil {
+		return nil, time.Time{}, false
+	}
+	return record.value, record.when, true
+}
+
+// ResetStats discards all collected invocation statistics.
+func (registry *Registry) ResetStats() {
+	registry.stats.Clear()
+}
+
+// AvgDuration returns the average duration of an invocation.
+func (stats FunctionStats) AvgDuration() time.Duration {
+	if stats.Calls == 0 {
+		return 0
+	}
+	return stats.TotalDuration / time.Duration(stats.Calls)
+}
+
+// AvgArgCount returns the average number of arguments of an invocation.
+func (stats FunctionStats) AvgArgCount() float64 {
+	return average(stats.TotalArgs, stats.Calls)
+}
+
+// AvgArgBytes returns the average estimated size of the arguments of an invocation.
+func (stats FunctionStats) AvgArgBytes() float64 {
+	return average(stats.ArgBytes, stats.Calls)
+}
+
+// AvgResultBytes returns the average estimated size of the results of an invocation.
+func (stats FunctionStats) AvgResultBytes() float64 {
+	return average(stats.ResultBytes, stats.Calls)
+}
+
+// OverheadRatio returns the ratio of the time spent in advice to the time spent in the target,
+// e.g. 2 when advice takes twice as long as the target, flagging functions where the overhead of
+// advice dominates a cheap target. It requires phase timing (see SetPhaseTiming) besides stats
+// and returns 0 until a target time was recorded.
+func (stats FunctionStats) OverheadRatio() float64 {
+	if stats.TargetDuration <= 0 {
+		return 0
+	}
+	return float64(stats.AdviceDuration) / float64(stats.TargetDuration)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// recordStats adds a completed invocation to the statistics of its function.
+func (registry *Registry) recordStats(c *Context, duration time.Duration) {
+	val, exists := registry.stats.Load(c.FunctionName)
+	if !exists {
+		val, _ = registry.stats.LoadOrStore(c.FunctionName, &funcStats{})
+	}
+	stats := val.(*funcStats)
+
+	stats.calls.Add(1)
+	switch {
+	case c.HasPanic():
+		stats.panics.Add(1)
+		stats.lastPanic.Store(&panicRecord{value: c.PanicValue, when: now()})
+	case c.Error != nil:
+		stats.errors.Add(1)
+	}
+
+	stats.args.Add(uint64(len(c.Args)))
+	if registry.sizeStats.Load() {
+		stats.argBytes.Add(estimateSizes(c.Args))
+		stats.resultBytes.Add(estimateSizes(c.Results))
+	}
+
+	if timings := c.PhaseTimings(); timings != nil {
+		for phase, elapsed := range timings {
+			if phase == PhaseTarget {
+				stats.targetNanos.Add(int64(elapsed))
+			} else {
+				stats.adviceNanos.Add(int64(elapsed))
+			}
+		}
+	}
+
+	nanos := int64(duration)
+	stats.totalNanos.Add(nanos)
+	for current := stats.maxNanos.Load(); nanos > current; current = stats.maxNanos.Load() {
+		if stats.maxNanos.CompareAndSwap(current, nanos) {
+			break
+		}
+	}
+}
+
+// snapshot returns the current values of the counters.
+func (stats *funcStats) snapshot() FunctionStats {
+	return FunctionStats{
+		Calls:         stats.calls.Load(),
+		Errors:        stats.errors.Load(),
+		Panics:        stats.panics.Load(),
+		TotalDuration: time.Duration(stats.totalNanos.Load()),
+		MaxDuration:   time.Duration(stats.maxNanos.Load()),
+		TotalArgs:     stats.args.Load(),
+		ArgBytes:      stats.argBytes.Load(),
+		ResultBytes:   stats.resultBytes.Load(),
+
+		AdviceDuration: time.Duration(stats.adviceNanos.Load()),
+		TargetDuration: time.Duration(stats.targetNanos.Load()),
+	}
+}
+
+// statsKeys returns the sorted keys of all functions with statistics.
+func (registry *Registry) statsKeys() []FuncKey {
+	var funcKeys []FuncKey
+	registry.stats.Range(func(key, _ any) bool {
+		funcKeys = append(funcKeys, key.(FuncKey))
+		return true
+	})
+	sort.Slice(funcKeys, func(i, j int) bool { return funcKeys[i] < funcKeys[j] })
+	return funcKeys
+}
+
+// average returns total/count, or 0 without count.
+func average(total, count uint64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count)
+}
+
+// estimateSizes returns the summed estimated size of values (see EnableSizeStats).
+func estimateSizes(values []any) uint64 {
+	var total uint64
+	for _, val := range values {
+		if val != nil {
+			total += estimateSize(reflect.ValueOf(val), 1)
+		}
+	}
+	return total
+}
+
+// estimateSize estimates the size of v: the value itself plus the backing data of strings,
+// slices and maps, following pointers and interfaces depth levels deep.
+func estimateSize(v reflect.Value, depth int) uint64 {
+	size := uint64(v.Type().Size())
+	switch v.Kind() {
+	case reflect.String:
+		size += uint64(v.Len())
+	case reflect.Slice:
+		size += uint64(v.Len()) * uint64(v.Type().Elem().Size())
+	case reflect.Map:
+		size += uint64(v.Len()) * uint64(v.Type().Key().Size()+v.Type().Elem().Size())
+	case reflect.Pointer, reflect.Interface:
+		if depth > 0 && !v.IsNil() {
+			size += estimateSize(v.Elem(), depth-1)
+		}
+	}
+	return size
+}
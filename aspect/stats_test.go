@@ -0,0 +1,183 @@
+// Package aspect - stats_test validates per-function invocation statistics
+package aspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestRegistry_Stats(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Divide")
+	registry.MustAddAdvice("Divide", Advice{Type: Before, Handler: func(c *Context) error { return nil }})
+
+	divide := Wrap2RE(registry, "Divide", func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		if a < 0 {
+			panic("negative")
+		}
+		time.Sleep(time.Millisecond)
+		return a / b, nil
+	})
+
+	_, _ = divide(4, 2) // Not recorded, stats are off
+	if stats := registry.Stats("Divide"); stats != (FunctionStats{}) {
+		t.Fatalf("expected no stats while disabled, got %+v", stats)
+	}
+
+	registry.EnableStats(true)
+	_, _ = divide(4, 2)
+	_, _ = divide(6, 3)
+	_, _ = divide(1, 0)
+	_, _ = divide(-1, 1)
+
+	stats := registry.Stats("Divide")
+	if stats.Calls != 4 || stats.Errors != 1 || stats.Panics != 1 {
+		t.Errorf("expected 4 calls, 1 error and 1 panic, got %+v", stats)
+	}
+	if stats.MaxDuration < time.Millisecond || stats.TotalDuration < 2*time.Millisecond {
+		t.Errorf("expected durations to include the sleeping calls, got %+v", stats)
+	}
+	if stats.AvgDuration() != stats.TotalDuration/4 {
+		t.Errorf("expected average of %v, got %v", stats.TotalDuration/4, stats.AvgDuration())
+	}
+
+	// Functions without advice are recorded too
+	Wrap0(registry, "Plain", func() {})()
+	if registry.Stats("Plain").Calls != 1 {
+		t.Errorf("expected 1 call of an unadvised function, got %+v", registry.Stats("Plain"))
+	}
+
+	registry.ResetStats()
+	if stats := registry.Stats("Divide"); stats.Calls != 0 {
+		t.Errorf("expected stats to be reset, got %+v", stats)
+	}
+}
+
+func TestRegistry_LastPanic(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.EnableStats(true)
+
+	getUser := Wrap1E(registry, "GetUser", func(id int) error {
+		if id < 0 {
+			panic("invalid id")
+		}
+		return nil
+	})
+
+	_ = getUser(1)
+	if _, _, ok := registry.LastPanic("GetUser"); ok {
+		t.Fatal("expected no panic recorded before the function panicked")
+	}
+
+	before := time.Now()
+	_ = getUser(-1)
+	_ = getUser(2)
+
+	value, when, ok := registry.LastPanic("GetUser")
+	if !ok {
+		t.Fatal("expected the panic to be recorded")
+	}
+	if value != "invalid id" {
+		t.Errorf("expected panic value 'invalid id', got %v", value)
+	}
+	if when.Before(before) || time.Since(when) > time.Second {
+		t.Errorf("expected a recent timestamp, got %v", when)
+	}
+
+	registry.ResetStats()
+	if _, _, ok := registry.LastPanic("GetUser"); ok {
+		t.Error("expected reset to discard the recorded panic")
+	}
+}
+
+func TestRegistry_StatsArgSizes(t *testing.T) {
+	registry := NewRegistry()
+	registry.EnableStats(true)
+
+	logf := func(args ...any) {
+		registry.Execute(context.Background(), "Log", func(c *Context) { c.SetResult(0, "ok") }, args...)
+	}
+	logf("a")
+	logf("a", 1)
+	logf("a", 1, 2.5)
+	logf("a", 1, 2.5, true)
+
+	stats := registry.Stats("Log")
+	if stats.TotalArgs != 10 || stats.AvgArgCount() != 2.5 {
+		t.Errorf("expected 10 arguments in total and 2.5 on average, got %d and %v", stats.TotalArgs, stats.AvgArgCount())
+	}
+	if stats.ArgBytes != 0 || stats.AvgArgBytes() != 0 {
+		t.Errorf("expected no size estimates unless enabled, got %+v", stats)
+	}
+
+	registry.ResetStats()
+	registry.EnableSizeStats(true)
+	logf("abcd", make([]byte, 8))
+
+	// A string header plus its 4 bytes, a slice header plus its 8 bytes
+	stats = registry.Stats("Log")
+	if expected := uint64(16 + 4 + 24 + 8); stats.ArgBytes != expected {
+		t.Errorf("expected %d argument bytes, got %d", expected, stats.ArgBytes)
+	}
+	if expected := uint64(16 + 2); stats.ResultBytes != expected {
+		t.Errorf("expected %d result bytes, got %d", expected, stats.ResultBytes)
+	}
+
+	if (FunctionStats{}).AvgArgCount() != 0 {
+		t.Error("expected zero average without calls")
+	}
+}
+
+func TestRegistry_StatsOverheadRatio(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	SetClock(clock)
+	defer SetClock(nil)
+
+	registry := NewRegistry()
+	registry.EnableStats(true)
+	registry.SetPhaseTiming(true)
+
+	advise := func(funcKey FuncKey, cost time.Duration) {
+		registry.MustRegister(funcKey)
+		registry.MustAddAdvice(funcKey, Advice{Type: Before, Handler: func(c *Context) error {
+			clock.Advance(cost)
+			return nil
+		}})
+		registry.MustAddAdvice(funcKey, Advice{Type: After, Handler: func(c *Context) error {
+			clock.Advance(cost)
+			return nil
+		}})
+	}
+
+	// A trivial target with heavy advice
+	advise("Cheap", 5*time.Millisecond)
+	Wrap0(registry, "Cheap", func() { clock.Advance(time.Millisecond) })()
+
+	// A heavy target with light advice
+	advise("Heavy", time.Millisecond)
+	Wrap0(registry, "Heavy", func() { clock.Advance(200 * time.Millisecond) })()
+
+	cheap, heavy := registry.Stats("Cheap"), registry.Stats("Heavy")
+	if ratio := cheap.OverheadRatio(); ratio != 10 {
+		t.Errorf("expected advice to dominate the cheap target with a ratio of 10, got %v (%+v)", ratio, cheap)
+	}
+	if ratio := heavy.OverheadRatio(); ratio != 0.01 {
+		t.Errorf("expected a low ratio of 0.01 for the heavy target, got %v (%+v)", ratio, heavy)
+	}
+
+	// Without phase timing no ratio is computed
+	registry.SetPhaseTiming(false)
+	registry.ResetStats()
+	Wrap0(registry, "Cheap", func() {})()
+	if ratio := registry.Stats("Cheap").OverheadRatio(); ratio != 0 {
+		t.Errorf("expected no ratio without phase timing, got %v", ratio)
+	}
+}
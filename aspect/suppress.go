@@ -0,0 +1,48 @@
+// Package aspect - suppress provides per-call suppression of named advice through the context
+package aspect
+
+import "context"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// suppressKey is the context key of the set of suppressed advice names.
+type suppressKey struct{}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Suppress returns a copy of ctx in which advice with the given names (see Advice.Name) is skipped,
+// e.g. to bypass caching for a forced refresh without removing the advice:
+//
+//	user, err := GetUser(aspect.Suppress(ctx, "cache"), id)
+//
+// Names add to the ones already suppressed by ctx. Only context-aware wrappers and Execute
+// see the suppression, as the others run with a background context.
+func Suppress(ctx context.Context, names ...string) context.Context {
+	existing, _ := ctx.Value(suppressKey{}).(map[string]struct{})
+	suppressed := make(map[string]struct{}, len(existing)+len(names))
+	for name := range existing {
+		suppressed[name] = struct{}{}
+	}
+	for _, name := range names {
+		suppressed[name] = struct{}{}
+	}
+	return context.WithValue(ctx, suppressKey{}, suppressed)
+}
+
+// IsSuppressed reports whether advice with the given name is suppressed in ctx.
+func IsSuppressed(ctx context.Context, name string) bool {
+	return isSuppressed(ctx, name)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// isSuppressed reports whether the named advice is suppressed. Unnamed advice never is,
+// which keeps the context lookup off the path of unnamed advice.
+func isSuppressed(ctx context.Context, name string) bool {
+	if name == "" {
+		return false
+	}
+	suppressed, _ := ctx.Value(suppressKey{}).(map[string]struct{})
+	_, exists := suppressed[name]
+	return exists
+}
@@ -0,0 +1,54 @@
+// Package aspect - suppress_test validates per-call suppression of named advice
+package aspect
+
+import (
+	"context"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestSuppress(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetPrice")
+
+	cache := map[string]int{"apple": 1}
+	registry.MustAddAdvice("GetPrice", Advice{
+		Type: Around,
+		Name: "cache",
+		Handler: func(c *Context) error {
+			if price, hit := cache[c.Args[0].(string)]; hit {
+				c.SetResult(0, price)
+				c.Skipped = true
+			}
+			return nil
+		},
+	})
+
+	var fetches int
+	getPrice := Wrap1RECtx(registry, "GetPrice", func(ctx context.Context, item string) (int, error) {
+		fetches++
+		return 2, nil
+	})
+
+	if price, _ := getPrice(context.Background(), "apple"); price != 1 || fetches != 0 {
+		t.Errorf("expected cached price 1 without fetching, got %d (fetches=%d)", price, fetches)
+	}
+
+	refresh := Suppress(context.Background(), "cache")
+	if price, _ := getPrice(refresh, "apple"); price != 2 || fetches != 1 {
+		t.Errorf("expected fresh price 2 with the cache suppressed, got %d (fetches=%d)", price, fetches)
+	}
+
+	if price, _ := getPrice(context.Background(), "apple"); price != 1 || fetches != 1 {
+		t.Errorf("expected the cache to be active again, got %d (fetches=%d)", price, fetches)
+	}
+
+	both := Suppress(refresh, "audit")
+	if !IsSuppressed(both, "cache") || !IsSuppressed(both, "audit") || IsSuppressed(refresh, "audit") {
+		t.Error("expected suppression sets to accumulate without affecting the parent context")
+	}
+	if IsSuppressed(both, "") {
+		t.Error("expected unnamed advice never to be suppressed")
+	}
+}
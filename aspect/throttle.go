@@ -0,0 +1,99 @@
+// Package aspect - throttle provides Around advice running a target at most once per interval and key
+package aspect
+
+import (
+	"sync"
+	"time"
+)
+
+// -------------------------------------------- Types --------------------------------------------
+
+// throttleEntry is the latest run of a throttled key.
+type throttleEntry struct {
+	start time.Time
+	call  *idempotentCall
+}
+
+// throttle holds the latest run of every throttled key of a Throttle advice.
+type throttle struct {
+	interval  time.Duration
+	keyFunc   func(*Context) string
+	mu        sync.Mutex
+	lastSweep time.Time
+	entries   map[string]*throttleEntry
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Throttle returns Around advice running the target at most once per interval for each key,
+// e.g. for UI-driven or event-flood scenarios. The key is computed from the invocation by
+// keyFunc (DefaultKey if nil). Calls within the interval of the latest run skip the target and
+// receive that run's results and error, waiting for it if it is still in progress; a waiter
+// whose context is cancelled gives up with the context error.
+//
+// The interval is measured from the start of a run on the configured clock (see SetClock).
+// Runs whose target did not run or panicked are not throttled against. Keys whose interval
+// elapsed are evicted by a sweep running at most once per interval, so the advice keeps no
+// state for keys that are no longer called.
+//
+// The advice should have a high priority so that it runs before other Around advice.
+func Throttle(interval time.Duration, keyFunc func(*Context) string) AdviceFunc {
+	return newThrottle(interval, keyFunc).advise
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// newThrottle creates the state of a Throttle advice.
+func newThrottle(interval time.Duration, keyFunc func(*Context) string) *throttle {
+	if keyFunc == nil {
+		keyFunc = DefaultKey
+	}
+	return &throttle{interval: interval, keyFunc: keyFunc, entries: make(map[string]*throttleEntry)}
+}
+
+// advise is the Around advice of Throttle.
+func (th *throttle) advise(c *Context) error {
+	key := th.keyFunc(c)
+	start := now()
+
+	th.mu.Lock()
+	if start.Sub(th.lastSweep) >= th.interval {
+		th.sweep(start)
+	}
+	if entry, exists := th.entries[key]; exists && start.Sub(entry.start) < th.interval {
+		th.mu.Unlock()
+		select {
+		case <-entry.call.done:
+			replayRecord(c, entry.call.record)
+			return nil
+		case <-c.Context().Done():
+			return c.Context().Err()
+		}
+	}
+
+	entry := &throttleEntry{start: start, call: &idempotentCall{done: make(chan struct{})}}
+	th.entries[key] = entry
+	th.mu.Unlock()
+
+	c.OnComplete(func(c *Context) {
+		th.mu.Lock()
+		entry.call.record = idempotentRecord{results: append([]any(nil), c.Results...), err: c.Error}
+		if (!c.TargetRan() || c.HasPanic()) && th.entries[key] == entry {
+			delete(th.entries, key)
+		}
+		th.mu.Unlock()
+
+		close(entry.call.done)
+	})
+	return nil
+}
+
+// sweep removes the entries whose interval elapsed at the given time. The caller must hold the lock.
+func (th *throttle) sweep(at time.Time) {
+	for key, entry := range th.entries {
+		if at.Sub(entry.start) >= th.interval {
+			delete(th.entries, key)
+		}
+	}
+	th.lastSweep = at
+}
@@ -0,0 +1,124 @@
+// Package aspect - throttle_test validates at-most-once execution per interval and key
+package aspect
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Test Helpers --------------------------------------------
+
+// size returns the number of keys the throttle holds state for.
+func (th *throttle) size() int {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	return len(th.entries)
+}
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestThrottle_RapidCalls(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	SetClock(clock)
+	defer SetClock(nil)
+
+	registry := NewRegistry()
+	registry.MustRegister("Search")
+	registry.MustAddAdvice("Search", Advice{
+		Type:     Around,
+		Priority: 100,
+		Handler:  Throttle(100*time.Millisecond, FieldsKey(0)),
+	})
+
+	var runs atomic.Int32
+	search := Wrap1RE(registry, "Search", func(query string) (int, error) {
+		return int(runs.Add(1)), nil
+	})
+
+	// A burst of concurrent calls within one interval runs the target once
+	var wg sync.WaitGroup
+	results := make([]int, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = search("go")
+		}(i)
+	}
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Errorf("expected target to run once per interval, ran %d times", runs.Load())
+	}
+	for i, result := range results {
+		if result != 1 {
+			t.Errorf("caller %d: expected the last result 1, got %d", i, result)
+		}
+	}
+
+	// Rapid calls over several intervals run the target once per interval
+	for step := 0; step < 30; step++ {
+		clock.Advance(10 * time.Millisecond)
+		_, _ = search("go")
+	}
+	if runs.Load() != 4 {
+		t.Errorf("expected one run per elapsed interval (4 in total), ran %d times", runs.Load())
+	}
+
+	// Keys are throttled independently
+	if result, _ := search("aop"); result != 5 {
+		t.Errorf("expected a different key to run the target, got %d", result)
+	}
+}
+
+func TestThrottle_PanicNotThrottled(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Flaky")
+	registry.MustAddAdvice("Flaky", Advice{Type: Around, Handler: Throttle(time.Hour, nil)})
+
+	var runs int
+	flaky := Wrap0E(registry, "Flaky", func() error {
+		runs++
+		if runs == 1 {
+			panic("boom")
+		}
+		return nil
+	})
+
+	if err := flaky(); err == nil {
+		t.Fatal("expected the panic to surface as an error")
+	}
+	if err := flaky(); err != nil || runs != 2 {
+		t.Errorf("expected the target to run again after a panic, got %v (runs=%d)", err, runs)
+	}
+}
+
+func TestThrottle_EvictsStaleKeys(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	SetClock(clock)
+	defer SetClock(nil)
+
+	th := newThrottle(100*time.Millisecond, FieldsKey(0))
+	registry := NewRegistry()
+	registry.MustRegister("Search")
+	registry.MustAddAdvice("Search", Advice{Type: Around, Priority: 100, Handler: th.advise})
+	search := Wrap1R(registry, "Search", func(query string) string { return query })
+
+	for i := 0; i < 50; i++ {
+		search(fmt.Sprintf("query-%d", i))
+	}
+	if got := th.size(); got != 50 {
+		t.Fatalf("expected 50 throttled keys, got %d", got)
+	}
+
+	// Once the interval elapsed, the next call sweeps the stale keys
+	clock.Advance(100 * time.Millisecond)
+	search("fresh")
+	if got := th.size(); got != 1 {
+		t.Errorf("expected stale keys to be evicted, got %d keys", got)
+	}
+}
@@ -0,0 +1,42 @@
+// Package aspect - trace reports the advice an invocation actually runs through the context
+package aspect
+
+import "context"
+
+// -------------------------------------------- Types --------------------------------------------
+
+// traceKey is the context key of the advice tracer.
+type traceKey struct{}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// WithAdviceTrace returns a copy of ctx in which every advice about to run is reported to
+// tracer, e.g. to assert the execution order in tests (see aspecttest.AssertOrder). Unlike
+// ExecutionPlan, skipped advice (conditions, disabled groups, suppression) and shadow advice are
+// not reported. The step's Priority is the advice's own priority. Parallel After advice reports
+// concurrently, so tracer must be safe for concurrent use if the function uses it.
+// Only context-aware wrappers and Execute see the tracer.
+func WithAdviceTrace(ctx context.Context, tracer func(step PlanStep)) context.Context {
+	return context.WithValue(ctx, traceKey{}, tracer)
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// traceAdvice reports advice about to run to the tracer of the invocation's context, if any.
+func (c *Context) traceAdvice(advice Advice) {
+	tracer, ok := c.Context().Value(traceKey{}).(func(step PlanStep))
+	if !ok {
+		return
+	}
+
+	name := advice.Name
+	if name == "" {
+		name = handlerName(advice.Handler)
+	}
+	tracer(PlanStep{
+		Phase:       adviceTypeNames[advice.Type],
+		Name:        name,
+		Priority:    advice.Priority,
+		Conditional: advice.Condition != nil || advice.Group != "",
+	})
+}
@@ -0,0 +1,39 @@
+// Package aspect - trace_test validates reporting of the advice an invocation runs
+package aspect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWithAdviceTrace(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("Save")
+	noop := func(c *Context) error { return nil }
+	registry.MustAddAdvice("Save", Advice{Type: Before, Name: "log", Priority: 10, Handler: noop})
+	registry.MustAddAdvice("Save", Advice{Type: Before, Name: "auth", Priority: 20, Handler: noop})
+	registry.MustAddAdvice("Save", Advice{Type: Before, Name: "never", Handler: noop,
+		Condition: func(c *Context) bool { return false }})
+	registry.MustAddAdvice("Save", Advice{Type: After, Name: "audit", Handler: noop})
+
+	var steps []string
+	ctx := WithAdviceTrace(context.Background(), func(step PlanStep) {
+		steps = append(steps, step.Phase+":"+step.Name)
+	})
+	registry.Execute(ctx, "Save", func(c *Context) { steps = append(steps, PhaseTarget) })
+
+	expected := []string{"Before:auth", "Before:log", PhaseTarget, "After:audit"}
+	if !reflect.DeepEqual(steps, expected) {
+		t.Errorf("expected %v, got %v", expected, steps)
+	}
+
+	// Invocations without a tracer are not reported
+	steps = nil
+	registry.Execute(context.Background(), "Save", func(c *Context) {})
+	if len(steps) != 0 {
+		t.Errorf("expected no steps without a tracer, got %v", steps)
+	}
+}
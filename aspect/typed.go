@@ -0,0 +1,60 @@
+// Package aspect - typed provides typed function handles that share one key between advice and invocation
+package aspect
+
+// -------------------------------------------- Types --------------------------------------------
+
+// TypedFunc is a typed handle to a registered function. It carries the function key, so
+// advice installation and invocation go through the same value instead of matching strings.
+type TypedFunc[A, R any] struct {
+	registry *Registry
+	funcKey  FuncKey
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Define1RE registers funcKey and returns the wrapped callable together with its typed handle.
+// The handle is used to attach advice, the callable to invoke the function:
+//
+//	getUser, getUserAspect := aspect.Define1RE(registry, "GetUser", repo.GetUser)
+//	getUserAspect.Before(logArgs).AfterThrowing(report)
+//	user, err := getUser(42)
+func Define1RE[A, R any](registry *Registry, funcKey FuncKey, impl func(A) (R, error)) (func(A) (R, error), *TypedFunc[A, R]) {
+	registry.RegisterOrGet(funcKey)
+	return Wrap1RE(registry, funcKey, impl), &TypedFunc[A, R]{registry: registry, funcKey: funcKey}
+}
+
+// Key returns the function key of the handle.
+func (tf *TypedFunc[A, R]) Key() FuncKey {
+	return tf.funcKey
+}
+
+// Advise adds the given advice to the function.
+func (tf *TypedFunc[A, R]) Advise(advice Advice) *TypedFunc[A, R] {
+	tf.registry.MustAddAdvice(tf.funcKey, advice)
+	return tf
+}
+
+// Before adds a Before advice to the function.
+func (tf *TypedFunc[A, R]) Before(handler AdviceFunc) *TypedFunc[A, R] {
+	return tf.Advise(Advice{Type: Before, Handler: handler})
+}
+
+// After adds an After advice to the function.
+func (tf *TypedFunc[A, R]) After(handler AdviceFunc) *TypedFunc[A, R] {
+	return tf.Advise(Advice{Type: After, Handler: handler})
+}
+
+// Around adds an Around advice to the function.
+func (tf *TypedFunc[A, R]) Around(handler AdviceFunc) *TypedFunc[A, R] {
+	return tf.Advise(Advice{Type: Around, Handler: handler})
+}
+
+// AfterReturning adds an AfterReturning advice to the function.
+func (tf *TypedFunc[A, R]) AfterReturning(handler AdviceFunc) *TypedFunc[A, R] {
+	return tf.Advise(Advice{Type: AfterReturning, Handler: handler})
+}
+
+// AfterThrowing adds an AfterThrowing advice to the function.
+func (tf *TypedFunc[A, R]) AfterThrowing(handler AdviceFunc) *TypedFunc[A, R] {
+	return tf.Advise(Advice{Type: AfterThrowing, Handler: handler})
+}
@@ -0,0 +1,75 @@
+// Package aspect - typed_test validates typed function handles
+package aspect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestDefine1RE_AdviceAndInvocationShareHandle(t *testing.T) {
+	registry := NewRegistry()
+
+	var order []string
+	getUser, getUserAspect := Define1RE(registry, "GetUser", func(id int) (string, error) {
+		order = append(order, "target")
+		if id < 0 {
+			return "", errors.New("invalid id")
+		}
+		return "alice", nil
+	})
+
+	getUserAspect.
+		Before(func(c *Context) error {
+			order = append(order, "before")
+			return nil
+		}).
+		AfterReturning(func(c *Context) error {
+			order = append(order, "afterReturning")
+			return nil
+		}).
+		After(func(c *Context) error {
+			order = append(order, "after")
+			return nil
+		})
+
+	name, err := getUser(1)
+	if err != nil || name != "alice" {
+		t.Fatalf("unexpected result: %q, %v", name, err)
+	}
+
+	expected := []string{"before", "target", "afterReturning", "after"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+	if !registry.IsRegistered(getUserAspect.Key()) {
+		t.Error("expected Define1RE to register the function")
+	}
+	if count := registry.GetAdviceCount(getUserAspect.Key()); count != 3 {
+		t.Errorf("expected 3 advice, got %d", count)
+	}
+
+	if _, err = getUser(-1); err == nil {
+		t.Error("expected error for negative id")
+	}
+}
+
+func TestDefine1RE_AroundSkip(t *testing.T) {
+	registry := NewRegistry()
+
+	getUser, getUserAspect := Define1RE(registry, "GetUser", func(id int) (string, error) {
+		t.Fatal("target should be skipped")
+		return "", nil
+	})
+	getUserAspect.Around(func(c *Context) error {
+		c.SetResult(0, "cached")
+		c.Skipped = true
+		return nil
+	})
+
+	if name, _ := getUser(1); name != "cached" {
+		t.Errorf("expected cached result, got %q", name)
+	}
+}
@@ -0,0 +1,93 @@
+// Package aspect - unused provides per-advice execution counters to detect advice that never fires
+package aspect
+
+// -------------------------------------------- Types --------------------------------------------
+
+// AdviceRef identifies a piece of advice applying to a function, with its execution counter.
+type AdviceRef struct {
+	FuncKey  FuncKey
+	Type     AdviceType
+	Name     string // Name is the advice name, or the handler's function name for unnamed advice.
+	Priority int
+	Fired    uint64 // Fired is the number of times the advice has been executed, across all functions it applies to.
+}
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// AdviceUsage returns the advice applying to funcKey, global and pattern advice included, in the
+// order of ExecutionPlan, with the number of times each has fired. Advice fires when it runs,
+// i.e. when it is not skipped by its condition, a disabled group or suppression.
+func (registry *Registry) AdviceUsage(funcKey FuncKey) []AdviceRef {
+	chain, err := registry.GetAdviceChain(funcKey)
+	if err != nil {
+		chain = NewAdviceChain() // Only global or pattern advice may apply
+	}
+
+	var refs []AdviceRef
+	for _, adviceType := range phaseOrder {
+		for _, advice := range registry.orderedAdvice(funcKey, chain, adviceType) {
+			name := advice.Name
+			if name == "" {
+				name = handlerName(advice.Handler)
+			}
+			refs = append(refs, AdviceRef{
+				FuncKey:  funcKey,
+				Type:     adviceType,
+				Name:     name,
+				Priority: advice.Priority,
+				Fired:    advice.firedCount(),
+			})
+		}
+	}
+	return refs
+}
+
+// UnusedAdvice returns the advice that has never fired although its function completed at least
+// minCalls invocations, e.g. a condition or pattern that never matches because of a configuration
+// bug. AfterReturning advice of a function that never succeeded and AfterThrowing advice of a
+// function that never panicked are not reported. Invocations are counted by the registry
+// statistics, so it requires EnableStats; functions are listed by key.
+func (registry *Registry) UnusedAdvice(minCalls uint64) []AdviceRef {
+	var unused []AdviceRef
+	for _, funcKey := range registry.statsKeys() {
+		stats := registry.Stats(funcKey)
+		if stats.Calls == 0 || stats.Calls < minCalls {
+			continue
+		}
+		for _, ref := range registry.AdviceUsage(funcKey) {
+			if ref.Fired == 0 && couldFire(ref.Type, stats) {
+				unused = append(unused, ref)
+			}
+		}
+	}
+	return unused
+}
+
+// -------------------------------------------- Private Helper Functions --------------------------------------------
+
+// markFired counts an execution of the advice. Advice not added through a chain is not counted.
+func (advice *Advice) markFired() {
+	if advice.fired != nil {
+		advice.fired.Add(1)
+	}
+}
+
+// firedCount returns the number of executions of the advice.
+func (advice *Advice) firedCount() uint64 {
+	if advice.fired == nil {
+		return 0
+	}
+	return advice.fired.Load()
+}
+
+// couldFire reports whether advice of the given type had a chance to run during the invocations
+// counted by stats: AfterReturning needs a successful one and AfterThrowing a panicking one.
+func couldFire(adviceType AdviceType, stats FunctionStats) bool {
+	switch adviceType {
+	case AfterReturning:
+		return stats.Calls > stats.Errors+stats.Panics
+	case AfterThrowing:
+		return stats.Panics > 0
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+// Package aspect - unused_test validates the per-advice execution counters
+package aspect
+
+import "testing"
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestAdviceUsage(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Name: "log", Handler: func(c *Context) error { return nil }})
+	registry.MustAddAdvice("GetUser", Advice{Type: After, Name: "odd", Condition: func(c *Context) bool {
+		return c.Args[0].(int)%2 == 1
+	}, Handler: func(c *Context) error { return nil }})
+
+	getUser := Wrap1R(registry, "GetUser", func(id int) int { return id })
+	for i := 0; i < 5; i++ {
+		getUser(i)
+	}
+
+	fired := map[string]uint64{}
+	for _, ref := range registry.AdviceUsage("GetUser") {
+		fired[ref.Name] = ref.Fired
+	}
+	if fired["log"] != 5 || fired["odd"] != 2 {
+		t.Errorf("expected log to fire 5 times and odd 2 times, got %v", fired)
+	}
+}
+
+func TestUnusedAdvice(t *testing.T) {
+	registry := NewRegistry()
+	registry.EnableStats(true)
+	registry.MustRegister("GetUser")
+	noop := func(c *Context) error { return nil }
+
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Name: "log", Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Name: "admin", Condition: func(c *Context) bool {
+		return c.Args[0] == "admin" // Never matches: callers pass ids
+	}, Handler: noop})
+	registry.MustAddAdvice("GetUser", Advice{Type: AfterThrowing, Name: "alert", Handler: noop})
+
+	getUser := Wrap1R(registry, "GetUser", func(id int) int { return id })
+	for i := 0; i < 10; i++ {
+		getUser(i)
+	}
+
+	if unused := registry.UnusedAdvice(100); len(unused) != 0 {
+		t.Errorf("expected no report below minCalls, got %v", unused)
+	}
+
+	unused := registry.UnusedAdvice(10)
+	if len(unused) != 1 {
+		t.Fatalf("expected 1 unused advice, got %v", unused)
+	}
+	if ref := unused[0]; ref.FuncKey != "GetUser" || ref.Type != Before || ref.Name != "admin" || ref.Fired != 0 {
+		t.Errorf("expected the admin advice of GetUser, got %+v", ref)
+	}
+}
@@ -4,6 +4,8 @@ package aspect
 import (
 	"context"
 	"fmt"
+	"runtime/trace"
+	"time"
 )
 
 // -------------------------------------------- Public Functions --------------------------------------------
@@ -12,8 +14,9 @@ import (
 
 // Wrap0 wraps a function with no arguments and no return values.
 func Wrap0(registry *Registry, funcKey FuncKey, fn func()) func() {
+	site := newWrapSite(registry, funcKey)
 	return func() {
-		executeWithAdvice(registry, funcKey, func(c *Context) {
+		executeWithAdvice(site, func(c *Context) {
 			fn()
 		})
 	}
@@ -21,8 +24,9 @@ func Wrap0(registry *Registry, funcKey FuncKey, fn func()) func() {
 
 // Wrap0Ctx wraps a function with context, no arguments, no returns.
 func Wrap0Ctx(registry *Registry, funcKey FuncKey, fn func(context.Context)) func(context.Context) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context) {
-		executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		executeWithAdviceContext(site, ctx, func(c *Context) {
 			fn(c.Context())
 		})
 	}
@@ -30,9 +34,10 @@ func Wrap0Ctx(registry *Registry, funcKey FuncKey, fn func(context.Context)) fun
 
 // Wrap0R wraps a function with no arguments and one return value.
 func Wrap0R[R any](registry *Registry, funcKey FuncKey, fn func() R) func() R {
+	site := newWrapSite(registry, funcKey)
 	return func() R {
 		var result R
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
 			result = fn()
 			c.SetResult(0, result)
 		})
@@ -42,9 +47,10 @@ func Wrap0R[R any](registry *Registry, funcKey FuncKey, fn func() R) func() R {
 
 // Wrap0RCtx wraps a function with context, no arguments, one return.
 func Wrap0RCtx[R any](registry *Registry, funcKey FuncKey, fn func(context.Context) R) func(context.Context) R {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context) R {
 		var result R
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
 			result = fn(c.Context())
 			c.SetResult(0, result)
 		})
@@ -54,9 +60,10 @@ func Wrap0RCtx[R any](registry *Registry, funcKey FuncKey, fn func(context.Conte
 
 // Wrap0E wraps a function with no arguments and returns error.
 func Wrap0E(registry *Registry, funcKey FuncKey, fn func() error) func() error {
+	site := newWrapSite(registry, funcKey)
 	return func() error {
 		var err error
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
 			err = fn()
 			c.Error = err
 		})
@@ -66,9 +73,10 @@ func Wrap0E(registry *Registry, funcKey FuncKey, fn func() error) func() error {
 
 // Wrap0ECtx wraps a function with context, no arguments, returns error.
 func Wrap0ECtx(registry *Registry, funcKey FuncKey, fn func(context.Context) error) func(context.Context) error {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context) error {
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
 			err = fn(c.Context())
 			c.Error = err
 		})
@@ -78,10 +86,11 @@ func Wrap0ECtx(registry *Registry, funcKey FuncKey, fn func(context.Context) err
 
 // Wrap0RE wraps a function with no arguments and returns (result, error).
 func Wrap0RE[R any](registry *Registry, funcKey FuncKey, fn func() (R, error)) func() (R, error) {
+	site := newWrapSite(registry, funcKey)
 	return func() (R, error) {
 		var result R
 		var err error
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
 			result, err = fn()
 			c.SetResult(0, result)
 			c.Error = err
@@ -92,10 +101,11 @@ func Wrap0RE[R any](registry *Registry, funcKey FuncKey, fn func() (R, error)) f
 
 // Wrap0RECtx wraps a function with context, no arguments, returns (result, error).
 func Wrap0RECtx[R any](registry *Registry, funcKey FuncKey, fn func(context.Context) (R, error)) func(context.Context) (R, error) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
 			result, err = fn(c.Context())
 			c.SetResult(0, result)
 			c.Error = err
@@ -108,8 +118,13 @@ func Wrap0RECtx[R any](registry *Registry, funcKey FuncKey, fn func(context.Cont
 
 // Wrap1 wraps a function with one argument and no return values.
 func Wrap1[A any](registry *Registry, funcKey FuncKey, fn func(A)) func(A) {
+	site := newWrapSite(registry, funcKey)
 	return func(a A) {
-		executeWithAdvice(registry, funcKey, func(c *Context) {
+		executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			fn(a)
 		}, a)
 	}
@@ -117,8 +132,13 @@ func Wrap1[A any](registry *Registry, funcKey FuncKey, fn func(A)) func(A) {
 
 // Wrap1Ctx wraps a function with context, 1 arg, no returns.
 func Wrap1Ctx[A any](registry *Registry, funcKey FuncKey, fn func(context.Context, A)) func(context.Context, A) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A) {
-		executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			fn(c.Context(), a)
 		}, a)
 	}
@@ -126,9 +146,18 @@ func Wrap1Ctx[A any](registry *Registry, funcKey FuncKey, fn func(context.Contex
 
 // Wrap1R wraps a function with one argument and one return value.
 func Wrap1R[A, R any](registry *Registry, funcKey FuncKey, fn func(A) R) func(A) R {
+	return wrap1R(newWrapSite(registry, funcKey), fn)
+}
+
+// wrap1R implements Wrap1R for an existing wrap site.
+func wrap1R[A, R any](site *wrapSite, fn func(A) R) func(A) R {
 	return func(a A) R {
 		var result R
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			result = fn(a)
 			c.SetResult(0, result)
 		}, a)
@@ -138,9 +167,14 @@ func Wrap1R[A, R any](registry *Registry, funcKey FuncKey, fn func(A) R) func(A)
 
 // Wrap1RCtx wraps a function with context, 1 arg, one return.
 func Wrap1RCtx[A, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A) R) func(context.Context, A) R {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A) R {
 		var result R
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			result = fn(c.Context(), a)
 			c.SetResult(0, result)
 		}, a)
@@ -150,21 +184,31 @@ func Wrap1RCtx[A, R any](registry *Registry, funcKey FuncKey, fn func(context.Co
 
 // Wrap1E wraps a function with one argument and returns error.
 func Wrap1E[A any](registry *Registry, funcKey FuncKey, fn func(A) error) func(A) error {
+	site := newWrapSite(registry, funcKey)
 	return func(a A) error {
 		var err error
-		executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			err = fn(a)
 			c.Error = err
 		}, a)
-		return err // executeWithAdvice returns *Context, but simplistic wrapper can just return captured err if no complex mutation needed
+		return resolveError(c, err)
 	}
 }
 
 // Wrap1ECtx wraps a function with context, 1 arg, returns error.
 func Wrap1ECtx[A any](registry *Registry, funcKey FuncKey, fn func(context.Context, A) error) func(context.Context, A) error {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A) error {
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			err = fn(c.Context(), a)
 			c.Error = err
 		}, a)
@@ -174,10 +218,19 @@ func Wrap1ECtx[A any](registry *Registry, funcKey FuncKey, fn func(context.Conte
 
 // Wrap1RE wraps a function with one argument and returns (result, error).
 func Wrap1RE[A, R any](registry *Registry, funcKey FuncKey, fn func(A) (R, error)) func(A) (R, error) {
+	return wrap1RE(newWrapSite(registry, funcKey), fn)
+}
+
+// wrap1RE implements Wrap1RE for an existing wrap site.
+func wrap1RE[A, R any](site *wrapSite, fn func(A) (R, error)) func(A) (R, error) {
 	return func(a A) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			result, err = fn(a)
 			c.SetResult(0, result)
 			c.Error = err
@@ -188,10 +241,19 @@ func Wrap1RE[A, R any](registry *Registry, funcKey FuncKey, fn func(A) (R, error
 
 // Wrap1RECtx wraps a function with context, 1 arg, returns (result, error).
 func Wrap1RECtx[A, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A) (R, error)) func(context.Context, A) (R, error) {
+	return wrap1RECtx(newWrapSite(registry, funcKey), fn)
+}
+
+// wrap1RECtx implements Wrap1RECtx for an existing wrap site.
+func wrap1RECtx[A, R any](site *wrapSite, fn func(context.Context, A) (R, error)) func(context.Context, A) (R, error) {
 	return func(ctx context.Context, a A) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
 			result, err = fn(c.Context(), a)
 			c.SetResult(0, result)
 			c.Error = err
@@ -200,12 +262,51 @@ func Wrap1RECtx[A, R any](registry *Registry, funcKey FuncKey, fn func(context.C
 	}
 }
 
+// Wrap1RECtxOut wraps a function with context, 1 arg, returning (context, result, error).
+//
+// This covers the rare targets that enrich the context they were given, e.g. attaching a
+// session or a logger. Once the target returns, its context replaces the invocation context,
+// so AfterReturning, AfterThrowing and After advice observe it through Context.Context().
+// The wrapper returns the target's context, or the incoming ctx when the target did not run.
+// The returned context carries the values of the target's context but the deadline and
+// cancellation of the incoming ctx, so it outlives the call even when the invocation ran with a
+// context derived internally, e.g. under a registry default timeout cancelled on return.
+func Wrap1RECtxOut[A, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A) (context.Context, R, error)) func(context.Context, A) (context.Context, R, error) {
+	site := newWrapSite(registry, funcKey)
+	return func(ctx context.Context, a A) (context.Context, R, error) {
+		outCtx := ctx
+		var result R
+		var err error
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
+			var enriched context.Context
+			enriched, result, err = fn(c.Context(), a)
+			if enriched != nil {
+				outCtx = detachedValues{Context: ctx, values: enriched}
+				c.ctx = enriched
+			}
+			c.SetResult(0, result)
+			c.Error = err
+		}, a)
+		result, err = resolveResultError(c, result, err)
+		return outCtx, result, err
+	}
+}
+
 // -- 2 Arguments --
 
 // Wrap2 wraps a function with two arguments and no return values.
 func Wrap2[A, B any](registry *Registry, funcKey FuncKey, fn func(A, B)) func(A, B) {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B) {
-		executeWithAdvice(registry, funcKey, func(c *Context) {
+		executeWithAdvice(site, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			fn(a, b)
 		}, a, b)
 	}
@@ -213,8 +314,13 @@ func Wrap2[A, B any](registry *Registry, funcKey FuncKey, fn func(A, B)) func(A,
 
 // Wrap2Ctx wraps a function with context, 2 args, no returns.
 func Wrap2Ctx[A, B any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B)) func(context.Context, A, B) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B) {
-		executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			fn(c.Context(), a, b)
 		}, a, b)
 	}
@@ -222,9 +328,14 @@ func Wrap2Ctx[A, B any](registry *Registry, funcKey FuncKey, fn func(context.Con
 
 // Wrap2R wraps a function with two arguments and one return value.
 func Wrap2R[A, B, R any](registry *Registry, funcKey FuncKey, fn func(A, B) R) func(A, B) R {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B) R {
 		var result R
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			result = fn(a, b)
 			c.SetResult(0, result)
 		}, a, b)
@@ -234,9 +345,14 @@ func Wrap2R[A, B, R any](registry *Registry, funcKey FuncKey, fn func(A, B) R) f
 
 // Wrap2RCtx wraps a function with context, 2 args, one return.
 func Wrap2RCtx[A, B, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B) R) func(context.Context, A, B) R {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B) R {
 		var result R
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			result = fn(c.Context(), a, b)
 			c.SetResult(0, result)
 		}, a, b)
@@ -246,9 +362,14 @@ func Wrap2RCtx[A, B, R any](registry *Registry, funcKey FuncKey, fn func(context
 
 // Wrap2E wraps a function with two arguments and returns error.
 func Wrap2E[A, B any](registry *Registry, funcKey FuncKey, fn func(A, B) error) func(A, B) error {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B) error {
 		var err error
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			err = fn(a, b)
 			c.Error = err
 		}, a, b)
@@ -258,9 +379,14 @@ func Wrap2E[A, B any](registry *Registry, funcKey FuncKey, fn func(A, B) error)
 
 // Wrap2ECtx wraps a function with context, 2 args, returns error.
 func Wrap2ECtx[A, B any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B) error) func(context.Context, A, B) error {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B) error {
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			err = fn(c.Context(), a, b)
 			c.Error = err
 		}, a, b)
@@ -270,10 +396,15 @@ func Wrap2ECtx[A, B any](registry *Registry, funcKey FuncKey, fn func(context.Co
 
 // Wrap2RE wraps a function with two arguments and returns (result, error).
 func Wrap2RE[A, B, R any](registry *Registry, funcKey FuncKey, fn func(A, B) (R, error)) func(A, B) (R, error) {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdvice(registry, funcKey, func(c *Context) {
+		c := executeWithAdvice(site, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			result, err = fn(a, b)
 			c.SetResult(0, result)
 			c.Error = err
@@ -284,10 +415,15 @@ func Wrap2RE[A, B, R any](registry *Registry, funcKey FuncKey, fn func(A, B) (R,
 
 // Wrap2RECtx wraps a function with context, 2 args, returns (result, error).
 func Wrap2RECtx[A, B, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B) (R, error)) func(context.Context, A, B) (R, error) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(c *Context) {
+		c := executeWithAdviceContext(site, ctx, func(c *Context) {
+			a, b, ok := args2(c, a, b)
+			if !ok {
+				return
+			}
 			result, err = fn(c.Context(), a, b)
 			c.SetResult(0, result)
 			c.Error = err
@@ -300,8 +436,13 @@ func Wrap2RECtx[A, B, R any](registry *Registry, funcKey FuncKey, fn func(contex
 
 // Wrap3 wraps a function with three arguments and no return values.
 func Wrap3[A, B, C any](registry *Registry, funcKey FuncKey, fn func(A, B, C)) func(A, B, C) {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B, c C) {
-		executeWithAdvice(registry, funcKey, func(ct *Context) {
+		executeWithAdvice(site, func(ct *Context) {
+			a, b, c, ok := args3(ct, a, b, c)
+			if !ok {
+				return
+			}
 			fn(a, b, c)
 		}, a, b, c)
 	}
@@ -309,8 +450,13 @@ func Wrap3[A, B, C any](registry *Registry, funcKey FuncKey, fn func(A, B, C)) f
 
 // Wrap3Ctx wraps a function with context, 3 args, no returns.
 func Wrap3Ctx[A, B, C any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B, C)) func(context.Context, A, B, C) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B, c C) {
-		executeWithAdviceContext(registry, funcKey, ctx, func(ct *Context) {
+		executeWithAdviceContext(site, ctx, func(ct *Context) {
+			a, b, c, ok := args3(ct, a, b, c)
+			if !ok {
+				return
+			}
 			fn(ct.Context(), a, b, c)
 		}, a, b, c)
 	}
@@ -318,9 +464,14 @@ func Wrap3Ctx[A, B, C any](registry *Registry, funcKey FuncKey, fn func(context.
 
 // Wrap3R wraps a function with three arguments and one return value.
 func Wrap3R[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(A, B, C) R) func(A, B, C) R {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B, paramC C) R {
 		var result R
-		c := executeWithAdvice(registry, funcKey, func(ct *Context) {
+		c := executeWithAdvice(site, func(ct *Context) {
+			a, b, paramC, ok := args3(ct, a, b, paramC)
+			if !ok {
+				return
+			}
 			result = fn(a, b, paramC)
 			ct.SetResult(0, result)
 		}, a, b, paramC)
@@ -330,9 +481,14 @@ func Wrap3R[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(A, B, C
 
 // Wrap3RCtx wraps a function with context, 3 args, one return.
 func Wrap3RCtx[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B, C) R) func(context.Context, A, B, C) R {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B, paramC C) R {
 		var result R
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(ct *Context) {
+		c := executeWithAdviceContext(site, ctx, func(ct *Context) {
+			a, b, paramC, ok := args3(ct, a, b, paramC)
+			if !ok {
+				return
+			}
 			result = fn(ct.Context(), a, b, paramC)
 			ct.SetResult(0, result)
 		}, a, b, paramC)
@@ -342,9 +498,14 @@ func Wrap3RCtx[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(cont
 
 // Wrap3E wraps a function with three arguments and returns error.
 func Wrap3E[A, B, C any](registry *Registry, funcKey FuncKey, fn func(A, B, C) error) func(A, B, C) error {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B, c C) error {
 		var err error
-		ctx := executeWithAdvice(registry, funcKey, func(ct *Context) {
+		ctx := executeWithAdvice(site, func(ct *Context) {
+			a, b, c, ok := args3(ct, a, b, c)
+			if !ok {
+				return
+			}
 			err = fn(a, b, c)
 			ct.Error = err
 		}, a, b, c)
@@ -354,9 +515,14 @@ func Wrap3E[A, B, C any](registry *Registry, funcKey FuncKey, fn func(A, B, C) e
 
 // Wrap3ECtx wraps a function with context, 3 args, returns error.
 func Wrap3ECtx[A, B, C any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B, C) error) func(context.Context, A, B, C) error {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B, c C) error {
 		var err error
-		ct := executeWithAdviceContext(registry, funcKey, ctx, func(ct *Context) {
+		ct := executeWithAdviceContext(site, ctx, func(ct *Context) {
+			a, b, c, ok := args3(ct, a, b, c)
+			if !ok {
+				return
+			}
 			err = fn(ct.Context(), a, b, c)
 			ct.Error = err
 		}, a, b, c)
@@ -366,10 +532,15 @@ func Wrap3ECtx[A, B, C any](registry *Registry, funcKey FuncKey, fn func(context
 
 // Wrap3RE wraps a function with three arguments and returns (result, error).
 func Wrap3RE[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(A, B, C) (R, error)) func(A, B, C) (R, error) {
+	site := newWrapSite(registry, funcKey)
 	return func(a A, b B, paramC C) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdvice(registry, funcKey, func(ct *Context) {
+		c := executeWithAdvice(site, func(ct *Context) {
+			a, b, paramC, ok := args3(ct, a, b, paramC)
+			if !ok {
+				return
+			}
 			result, err = fn(a, b, paramC)
 			ct.SetResult(0, result)
 			ct.Error = err
@@ -380,10 +551,15 @@ func Wrap3RE[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(A, B,
 
 // Wrap3RECtx wraps a function with context, 3 args, returns (result, error).
 func Wrap3RECtx[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(context.Context, A, B, C) (R, error)) func(context.Context, A, B, C) (R, error) {
+	site := newWrapSite(registry, funcKey)
 	return func(ctx context.Context, a A, b B, paramC C) (R, error) {
 		var result R
 		var err error
-		c := executeWithAdviceContext(registry, funcKey, ctx, func(ct *Context) {
+		c := executeWithAdviceContext(site, ctx, func(ct *Context) {
+			a, b, paramC, ok := args3(ct, a, b, paramC)
+			if !ok {
+				return
+			}
 			result, err = fn(ct.Context(), a, b, paramC)
 			ct.SetResult(0, result)
 			ct.Error = err
@@ -394,6 +570,24 @@ func Wrap3RECtx[A, B, C, R any](registry *Registry, funcKey FuncKey, fn func(con
 
 // -------------------------------------------- Private Helper Functions --------------------------------------------
 
+// detachedValues is a context with the deadline and cancellation of the embedded context and the
+// values of another one, detaching a context derived during an invocation from its cancellation.
+type detachedValues struct {
+	context.Context
+	values context.Context
+}
+
+// Value returns the value of key in the values context. The engine's per-invocation keys are
+// looked up in the embedded context, so the invocation's recursion marker and exposed Context
+// do not leak into later calls.
+func (ctx detachedValues) Value(key any) any {
+	switch key.(type) {
+	case activeCallsKey, exposeKey, batchKey:
+		return ctx.Context.Value(key)
+	}
+	return ctx.values.Value(key)
+}
+
 // resolveResult handles the logic for extracting a generic result from the context,
 // checking for advice skips, and performing safe type assertions.
 func resolveResult[R any](c *Context, original R) R {
@@ -403,6 +597,9 @@ func resolveResult[R any](c *Context, original R) R {
 			return res
 		}
 	}
+	if c != nil && c.Skipped && c.Error == nil {
+		c.registry.warnSkipNoResult(c)
+	}
 	return original
 }
 
@@ -423,27 +620,100 @@ func resolveResultError[R any](c *Context, origRes R, origErr error) (R, error)
 }
 
 // executeWithAdvice executes a function with full advice chain support and returns the context.
-func executeWithAdvice(registry *Registry, functionName FuncKey, targetFn func(*Context), args ...any) *Context {
-	return executeWithAdviceContext(registry, functionName, context.Background(), targetFn, args...)
+func executeWithAdvice(site *wrapSite, targetFn func(*Context), args ...any) *Context {
+	return executeInvocation(site, context.Background(), targetFn, args...)
+}
+
+// executeWithAdviceContext executes a context-aware function with full advice chain support using a specific context.Context.
+// The registry's default timeout, if any, is applied to the context for the whole invocation.
+func executeWithAdviceContext(site *wrapSite, ctx context.Context, targetFn func(*Context), args ...any) *Context {
+	if timeout := site.registry.DefaultTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout) // Keeps an earlier deadline of ctx
+		defer cancel()
+	}
+	return executeInvocation(site, ctx, targetFn, args...)
+}
+
+// executeInvocation executes a function with full advice chain support and returns the context,
+// recording the invocation in the registry's stats and invocation log when enabled.
+// In maintenance mode the invocation is short-circuited to the fallback.
+func executeInvocation(site *wrapSite, ctx context.Context, targetFn func(*Context), args ...any) *Context {
+	if mode := site.registry.maintenance.Load(); mode != nil {
+		return mode.runFallback(site.registry, ctx, site.funcKey, args...)
+	}
+
+	start := now()
+	c := invoke(site, ctx, start, targetFn, args...)
+	c.finish(now().Sub(start))
+
+	if site.registry.StatsEnabled() {
+		site.registry.recordStats(c, c.elapsed)
+	}
+	if log := site.registry.invocationLog.Load(); log != nil {
+		log.record(c, start, c.elapsed)
+	}
+	if site.options.repanic && c.HasPanic() {
+		panic(c.PanicValue)
+	}
+	return c
 }
 
-// executeWithAdviceContext executes a function with full advice chain support using a specific context.Context.
-func executeWithAdviceContext(registry *Registry, functionName FuncKey, ctx context.Context, targetFn func(*Context), args ...any) *Context {
-	// Get advice chain from registry
-	chain, err := registry.GetAdviceChain(functionName)
+// invoke executes a function with full advice chain support and returns the context.
+func invoke(site *wrapSite, ctx context.Context, start time.Time, targetFn func(*Context), args ...any) *Context {
+	registry, functionName := site.registry, site.funcKey
+
+	// Get advice chain and settings from registry (memoized per wrap site)
+	lookup := site.lookup()
+
+	// Guard against the function re-entering itself through advice
+	if policy := site.recursionPolicy(lookup); policy != RecursionAllow {
+		if isActive(ctx, functionName) {
+			c := NewContextWithContext(ctx, functionName, args...)
+			c.start = start
+			if policy == RecursionError {
+				c.Error = recursionError(functionName)
+				return c
+			}
+			c.targetRan = true
+			targetFn(c)
+			return c
+		}
+		ctx = markActive(ctx, functionName)
+	}
+
+	chain, err := lookup.chain, lookup.err
 	if err != nil {
-		// No advice registered, just execute target function
-		c := NewContextWithContext(ctx, functionName, args...)
-		targetFn(c)
-		return c
+		if !lookup.hasSharedAdvice() {
+			// No advice registered, just execute target function
+			c := NewContextWithContext(ctx, functionName, args...)
+			c.start = start
+			registry.expose(c)
+			c.targetRan = true
+			targetFn(c)
+			return c
+		}
+		// Only global or pattern advice applies to this function
+		chain = NewAdviceChain()
 	}
 
 	// Create execution context
 	c := NewContextWithContext(ctx, functionName, args...)
+	c.start = start
+	c.registry = registry
+	c.lookup = lookup
+	c.store = c.newMetadataStore()
+	c.maxMetadata = registry.MaxMetadataEntries()
+	if registry.phaseTiming.Load() {
+		c.phaseTimings = make(map[string]time.Duration)
+	}
+	c.traceRegions = registry.runtimeTrace.Load() && trace.IsEnabled()
+	registry.expose(c)
 
 	if err = executeWithChain(chain, targetFn, c); err != nil {
 		c.Error = err
 	}
+	c.complete()
 
 	return c
 }
@@ -452,7 +722,7 @@ func executeWithAdviceContext(registry *Registry, functionName FuncKey, ctx cont
 func executeWithChain(chain *AdviceChain, targetFn func(*Context), c *Context) (finalErr error) {
 	// Always execute After advice (even on panic/error)
 	defer func() {
-		if afterErr := chain.ExecuteAfter(c); afterErr != nil {
+		if afterErr := executeGuardedPhase(chain, After, c); afterErr != nil {
 			if finalErr != nil {
 				finalErr = fmt.Errorf("%w, after advice error: %v", finalErr, afterErr)
 			} else {
@@ -464,49 +734,137 @@ func executeWithChain(chain *AdviceChain, targetFn func(*Context), c *Context) (
 	defer func() {
 		if r := recover(); r != nil {
 			c.PanicValue = r
+			notifyPanic(c.FunctionName, r)
 
 			// Execute AfterThrowing advice for panic
-			if throwErr := chain.ExecuteAfterThrowing(c); throwErr != nil {
-				// Combine errors
-				finalErr = fmt.Errorf("panic: %v, afterThrowing error: %w", r, throwErr)
-			} else {
-				finalErr = fmt.Errorf("panic recovered: %v", r)
+			throwErr := executeGuardedPhase(chain, AfterThrowing, c)
+
+			// AfterThrowing advice may have replaced the panic value (e.g. to sanitize it)
+			if c.PanicValue == nil {
+				c.PanicValue = r
 			}
+			finalErr = panicError(c.PanicValue, throwErr)
 		}
 	}()
 
+	if c.timingPhases() {
+		targetFn = c.timedTarget(targetFn)
+	}
+	if c.traceRegions {
+		targetFn = c.tracedTarget(targetFn)
+	}
+
 	// Execute Before advice
-	if err := chain.ExecuteBefore(c); err != nil {
-		return fmt.Errorf("before advice failed: %w", err)
+	if err := executePhase(chain, Before, c); err != nil {
+		return phaseError(Before, err, c)
 	}
 
 	// Execute Around advice
-	if chain.HasAround() {
-		if err := chain.ExecuteAround(c); err != nil {
-			return fmt.Errorf("around advice failed: %w", err)
+	if around := c.adviceFor(chain, Around); len(around) > 0 {
+		if err := executeAround(around, targetFn, c); err != nil {
+			return phaseError(Around, err, c)
 		}
 		// If Around advice sets Skipped, we skip the target function
 		if c.Skipped {
 			// Execute AfterReturning if no error
 			if c.Error == nil {
-				if err := chain.ExecuteAfterReturning(c); err != nil {
-					return fmt.Errorf("afterReturning advice failed: %w", err)
+				if err := executeGuardedPhase(chain, AfterReturning, c); err != nil {
+					return &AdviceError{Phase: AfterReturning, Err: err}
 				}
 			}
 			return nil
 		}
 	}
 
-	// Execute Target Function (may panic, which is caught by defer)
-	targetFn(c)
+	// Execute Target Function (may panic, which is caught by defer), unless Around advice ran it
+	if !c.targetRan {
+		c.targetRan = true
+		targetFn(c)
+	}
 
 	// Execute AfterReturning advice (only if no error and no panic occurred)
 	if c.Error == nil && !c.HasPanic() {
-		if err := chain.ExecuteAfterReturning(c); err != nil {
-			return fmt.Errorf("afterReturning advice failed: %w", err)
+		if err := executeGuardedPhase(chain, AfterReturning, c); err != nil {
+			return &AdviceError{Phase: AfterReturning, Err: err}
 		}
 	}
 
 	// Return any error from the target function
 	return c.Error
 }
+
+// executePhase runs the advice of the given type for an invocation, including
+// any global and pattern advice of the executing registry.
+func executePhase(chain *AdviceChain, adviceType AdviceType, c *Context) error {
+	if c.timingPhases() {
+		defer c.addPhaseTiming(adviceTypeNames[adviceType], now())
+	}
+	if c.traceRegions {
+		defer c.startRegion(adviceTypeNames[adviceType]).End()
+	}
+
+	adviceList := c.adviceFor(chain, adviceType)
+	if (adviceType == After || adviceType == AfterReturning) && chain.parallelAfter.Load() {
+		return chain.executeAdviceParallel(adviceList, c)
+	}
+	return chain.executeAdviceList(adviceList, c)
+}
+
+// executeAround runs the Around advice of an invocation nested around the target (see
+// executeAroundList). With phase timing, the time of the target is not counted as Around time.
+func executeAround(around []Advice, targetFn func(*Context), c *Context) error {
+	if c.traceRegions {
+		defer c.startRegion(adviceTypeNames[Around]).End()
+	}
+	around = orderByPriority(around, c)
+	if !c.timingPhases() {
+		return executeAroundList(around, 0, targetFn, c)
+	}
+
+	start, targetBefore := now(), c.PhaseTimings()[PhaseTarget]
+	err := executeAroundList(around, 0, targetFn, c)
+	targetDuring := c.PhaseTimings()[PhaseTarget] - targetBefore
+	c.addPhaseTiming(adviceTypeNames[Around], start.Add(targetDuring))
+	return err
+}
+
+// executeGuardedPhase runs a phase that follows the target behind a recover boundary. A panicking
+// handler fails the phase with an ErrAdvicePanic error, also reported to the OnAdviceError handler,
+// instead of escaping the deferred lifecycle, so the remaining phases still run.
+func executeGuardedPhase(chain *AdviceChain, adviceType AdviceType, c *Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = advicePanicError(adviceType, r)
+			if c.registry != nil {
+				c.registry.reportAdviceError(c, err)
+			}
+		}
+	}()
+	return executePhase(chain, adviceType, c)
+}
+
+// phaseError wraps an error aborting the invocation in a phase. The context's own error is
+// returned as it is, so a cancellation or deadline surfaces cleanly as context.Canceled or
+// context.DeadlineExceeded.
+func phaseError(phase AdviceType, err error, c *Context) error {
+	if ctxErr := c.Context().Err(); ctxErr != nil && err == ctxErr {
+		return err
+	}
+	return &AdviceError{Phase: phase, Err: err}
+}
+
+// panicError converts a recovered panic value into an error. Panic values that are errors
+// are wrapped so callers can match them with errors.Is and errors.As.
+func panicError(value any, throwErr error) error {
+	if throwErr != nil {
+		if err, ok := value.(error); ok {
+			return fmt.Errorf("panic: %w, afterThrowing error: %w", err, throwErr)
+		}
+		return fmt.Errorf("panic: %v, afterThrowing error: %w", value, throwErr)
+	}
+
+	if err, ok := value.(error); ok {
+		return fmt.Errorf("panic recovered: %w", err)
+	}
+	return fmt.Errorf("panic recovered: %v", value)
+}
@@ -0,0 +1,31 @@
+// Package aspect - wrapx provides wrapper variants also returning the execution context
+package aspect
+
+// -------------------------------------------- Public Functions --------------------------------------------
+
+// Wrap1REx wraps a function with one argument returning (result, error) like Wrap1RE, and also
+// returns the execution context of each call, so callers can inspect metadata, the duration
+// (see Elapsed), whether the target was skipped, etc. without going through Execute:
+//
+//	user, err, c := getUser("alice")
+//	log.Printf("GetUser took %v (cached: %v)", c.Elapsed(), c.Skipped)
+//
+// Contexts are not pooled, so the returned context belongs to the caller and may be retained.
+func Wrap1REx[A, R any](registry *Registry, funcKey FuncKey, fn func(A) (R, error)) func(A) (R, error, *Context) {
+	site := newWrapSite(registry, funcKey)
+	return func(a A) (R, error, *Context) {
+		var result R
+		var err error
+		c := executeWithAdvice(site, func(c *Context) {
+			a, ok := args1(c, a)
+			if !ok {
+				return
+			}
+			result, err = fn(a)
+			c.SetResult(0, result)
+			c.Error = err
+		}, a)
+		result, err = resolveResultError(c, result, err)
+		return result, err, c
+	}
+}
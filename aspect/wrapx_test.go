@@ -0,0 +1,54 @@
+// Package aspect - wrapx_test validates wrapper variants returning the execution context
+package aspect
+
+import (
+	"testing"
+	"time"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestWrap1REx_ReturnsContext(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister("GetUser")
+
+	cache := map[string]string{"bob": "cached-bob"}
+	registry.MustAddAdvice("GetUser", Advice{Type: Around, Handler: func(c *Context) error {
+		if user, ok := cache[c.Arg(0).(string)]; ok {
+			c.SetResult(0, user)
+			c.Skipped = true
+		}
+		return nil
+	}})
+	registry.MustAddAdvice("GetUser", Advice{Type: Before, Handler: func(c *Context) error {
+		c.SetMetadataVal("source", "api")
+		return nil
+	}})
+
+	getUser := Wrap1REx(registry, "GetUser", func(name string) (string, error) {
+		time.Sleep(5 * time.Millisecond)
+		return "db-" + name, nil
+	})
+
+	user, err, c := getUser("alice")
+	if user != "db-alice" || err != nil {
+		t.Errorf("expected 'db-alice', got %q, %v", user, err)
+	}
+	if c.Skipped {
+		t.Error("expected the uncached call not to be skipped")
+	}
+	if c.Elapsed() < 5*time.Millisecond {
+		t.Errorf("expected the elapsed time to include the target, got %v", c.Elapsed())
+	}
+	if source, _ := c.GetMetadataVal("source"); source != "api" {
+		t.Errorf("expected metadata set by advice, got %v", source)
+	}
+
+	user, _, c = getUser("bob")
+	if user != "cached-bob" || !c.Skipped {
+		t.Errorf("expected a skipped cached call, got %q (skipped: %v)", user, c.Skipped)
+	}
+	if elapsed := c.Elapsed(); elapsed >= 5*time.Millisecond || elapsed != c.Elapsed() {
+		t.Errorf("expected a short and fixed elapsed time once completed, got %v", elapsed)
+	}
+}
@@ -114,8 +114,8 @@ func setupValidation() {
 		Priority: 110, // Higher priority, runs first
 		Handler: func(c *aspect.Context) error {
 			utils.LogBefore(c, 110, "VALIDATION")
-			userID := c.Args[0].(string)
-			amount := c.Args[1].(float64)
+			userID := c.Arg(0).(string)
+			amount := c.Arg(1).(float64)
 
 			if userID == "" {
 				log.Printf("   ❌ [VALIDATE] userID cannot be empty")
@@ -66,7 +66,7 @@ func setupAOP() {
 		Priority: 100,
 		Handler: func(c *aspect.Context) error {
 			utils.LogAround(c, 100, "CACHE CHECK")
-			userID := c.Args[0].(string)
+			userID := c.Arg(0).(string)
 			cacheKey := "user:" + userID
 
 			// Check cache
@@ -99,7 +99,7 @@ func setupAOP() {
 				return nil
 			}
 
-			userID := c.Args[0].(string)
+			userID := c.Arg(0).(string)
 
 			// Check if there are results and the first result is not nil
 			if len(c.Results) == 0 {
@@ -131,7 +131,7 @@ func setupAOP() {
 		Priority: 100,
 		Handler: func(c *aspect.Context) error {
 			utils.LogAround(c, 100, "TTL CACHE CHECK")
-			userID := c.Args[0].(string)
+			userID := c.Arg(0).(string)
 
 			cacheMu.RLock()
 			entry, exists := cacheStore[userID]
@@ -170,7 +170,7 @@ func setupAOP() {
 				return nil
 			}
 
-			userID := c.Args[0].(string)
+			userID := c.Arg(0).(string)
 			if len(c.Results) == 0 || c.Results[0] == nil {
 				log.Printf("   ⚠️  [CACHE] No result to cache")
 				return nil
@@ -55,7 +55,7 @@ func setupAOP() {
 			Priority: 100, // Highest - run first
 			Handler: func(c *aspect.Context) error {
 				utils.LogBefore(c, 100, "AUTHENTICATION")
-				token := c.Args[0].(string)
+				token := c.Arg(0).(string)
 
 				log.Printf("   🔐 [AUTH] Validating token: %s", token)
 				session, err := validateToken(token)
@@ -152,7 +152,7 @@ func setupAOP() {
 		Priority: 90,
 		Handler: func(c *aspect.Context) error {
 			utils.LogBefore(c, 90, "REQUEST LOG")
-			endpoint := c.Args[0].(string)
+			endpoint := c.Arg(0).(string)
 			log.Printf("   🌐 [REQUEST] Calling external service: %s", endpoint)
 			return nil
 		},
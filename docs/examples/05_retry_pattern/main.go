@@ -95,8 +95,8 @@ func setupAOP() {
 		Priority: 90,
 		Handler: func(c *aspect.Context) error {
 			utils.LogBefore(c, 90, "EMAIL LOG")
-			to := c.Args[0].(string)
-			subject := c.Args[1].(string)
+			to := c.Arg(0).(string)
+			subject := c.Arg(1).(string)
 			log.Printf("   📧 [EMAIL] Preparing to send email to: %s", to)
 			log.Printf("   📝 [EMAIL] Subject: %s", subject)
 			return nil
@@ -109,8 +109,8 @@ func setupAOP() {
 		Priority: 90,
 		Handler: func(c *aspect.Context) error {
 			utils.LogBefore(c, 90, "PAYMENT LOG")
-			amount := c.Args[0].(float64)
-			cardToken := c.Args[1].(string)
+			amount := c.Arg(0).(float64)
+			cardToken := c.Arg(1).(string)
 			log.Printf("   💳 [PAYMENT] Processing payment: $%.2f", amount)
 			log.Printf("   🔐 [PAYMENT] Card token: %s...", cardToken[:8])
 			return nil
@@ -60,8 +60,8 @@ func setupAOPWithFluentAPI() {
 	// Example 3: Validation with fluent API
 	aspect.For("CreateOrderFluent").
 		WithBefore(func(c *aspect.Context) error {
-			userID := c.Args[0].(string)
-			amount := c.Args[1].(float64)
+			userID := c.Arg(0).(string)
+			amount := c.Arg(1).(float64)
 
 			if userID == "" {
 				return errors.New("userID cannot be empty")
@@ -77,7 +77,7 @@ func setupAOPWithFluentAPI() {
 	cache := make(map[string]*User)
 	aspect.For("GetUserCached").
 		WithAround(func(c *aspect.Context) error {
-			userID := c.Args[0].(string)
+			userID := c.Arg(0).(string)
 
 			// Check cache first
 			if cachedUser, exists := cache[userID]; exists {
@@ -92,7 +92,7 @@ func setupAOPWithFluentAPI() {
 		}).
 		WithAfterReturning(func(c *aspect.Context) error {
 			// Populate cache after successful execution
-			userID := c.Args[0].(string)
+			userID := c.Arg(0).(string)
 			user := c.Results[0].(*User)
 			cache[userID] = user
 			log.Printf("💾 [FLUENT-CACHE] Cached user %s", userID)
@@ -41,13 +41,13 @@ func setupLogging() {
 	aspect.For("UserService.GetUser").
 		WithBefore(func(c *aspect.Context) error {
 			utils.LogBefore(c, 100, "LOGGING")
-			username := c.Args[0].(string)
+			username := c.Arg(0).(string)
 			log.Printf("   📝 [LOG] Starting GetUser for username: %s", username)
 			return nil
 		}).
 		WithAfter(func(c *aspect.Context) error {
 			utils.LogAfter(c, 100, "LOGGING")
-			username := c.Args[0].(string)
+			username := c.Arg(0).(string)
 			status := "SUCCESS"
 			if c.Error != nil {
 				status = "FAILED"
@@ -62,13 +62,13 @@ func setupLogging() {
 	aspect.For("UserService.CreateUser").
 		WithBefore(func(c *aspect.Context) error {
 			utils.LogBefore(c, 100, "LOGGING")
-			user := c.Args[0].(*User)
+			user := c.Arg(0).(*User)
 			log.Printf("   📝 [LOG] Starting CreateUser for user: %s", user.Username)
 			return nil
 		}).
 		WithAfter(func(c *aspect.Context) error {
 			utils.LogAfter(c, 100, "LOGGING")
-			user := c.Args[0].(*User)
+			user := c.Arg(0).(*User)
 			status := "SUCCESS"
 			if c.Error != nil {
 				status = "FAILED"
@@ -80,15 +80,15 @@ func setupLogging() {
 	aspect.For("OrderService.CreateOrder").
 		WithBefore(func(c *aspect.Context) error {
 			utils.LogBefore(c, 100, "LOGGING")
-			userID := c.Args[0].(string)
-			amount := c.Args[1].(float64)
+			userID := c.Arg(0).(string)
+			amount := c.Arg(1).(float64)
 			log.Printf("   📝 [LOG] Starting CreateOrder for user: %s, amount: %.2f", userID, amount)
 			return nil
 		}).
 		WithAfter(func(c *aspect.Context) error {
 			utils.LogAfter(c, 100, "LOGGING")
-			userID := c.Args[0].(string)
-			amount := c.Args[1].(float64)
+			userID := c.Arg(0).(string)
+			amount := c.Arg(1).(float64)
 			status := "SUCCESS"
 			if c.Error != nil {
 				status = "FAILED"
@@ -133,7 +133,7 @@ func setupValidation() {
 	aspect.For("UserService.CreateUser").
 		WithBefore(func(c *aspect.Context) error {
 			utils.LogBefore(c, 110, "VALIDATION")
-			user := c.Args[0].(*User)
+			user := c.Arg(0).(*User)
 
 			if user.Username == "" {
 				log.Printf("   ❌ [VALIDATE] Username cannot be empty")
@@ -156,8 +156,8 @@ func setupValidation() {
 	aspect.For("OrderService.CreateOrder").
 		WithBefore(func(c *aspect.Context) error {
 			utils.LogBefore(c, 110, "VALIDATION")
-			userID := c.Args[0].(string)
-			amount := c.Args[1].(float64)
+			userID := c.Arg(0).(string)
+			amount := c.Arg(1).(float64)
 
 			if userID == "" {
 				log.Printf("   ❌ [VALIDATE] UserID cannot be empty")
@@ -186,7 +186,7 @@ func setupCaching() {
 	// Around advice for caching GetUser
 	aspect.For("UserService.GetUser").
 		WithAround(func(c *aspect.Context) error {
-			username := c.Args[0].(string)
+			username := c.Arg(0).(string)
 
 			// Check cache first
 			if cachedUser, exists := userCache[username]; exists {
@@ -201,7 +201,7 @@ func setupCaching() {
 		}).
 		WithAfterReturning(func(c *aspect.Context) error {
 			// Populate cache after successful execution
-			username := c.Args[0].(string)
+			username := c.Arg(0).(string)
 			user := c.Results[0].(*User)
 			userCache[username] = user
 			log.Printf("   💾 [CACHE] Cached user: %s", username)
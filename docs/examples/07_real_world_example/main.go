@@ -0,0 +1,40 @@
+// Package main - real_world_example demonstrates a complete application setup with services and centralized AOP
+package main
+
+import (
+	"log"
+	"time"
+)
+
+func main() {
+	setupAOP()
+
+	log.Println("=== Global Wrapped Functions ===")
+	if err := UserServiceCreateUser(&User{ID: "1", Username: "alice", Email: "alice@example.com", Created: time.Now()}); err != nil {
+		log.Printf("❌ CreateUser failed: %v", err)
+	}
+	if user, err := UserServiceGetUser("alice"); err != nil {
+		log.Printf("❌ GetUser failed: %v", err)
+	} else {
+		log.Printf("👤 Got user: %s <%s>", user.Username, user.Email)
+	}
+	// Second lookup is served by the caching advice
+	if _, err := UserServiceGetUser("alice"); err != nil {
+		log.Printf("❌ GetUser failed: %v", err)
+	}
+	log.Println()
+
+	log.Println("=== Wrapped Service Structs ===")
+	services := NewWrappedServices()
+	order, err := services.OrderService.CreateOrder("1", 99.99)
+	if err != nil {
+		log.Printf("❌ CreateOrder failed: %v", err)
+	} else if _, err := services.OrderService.GetOrder(order.ID); err != nil {
+		log.Printf("❌ GetOrder failed: %v", err)
+	}
+
+	// Validation rejects invalid input before the business logic runs
+	if _, err := services.OrderService.CreateOrder("1", -5); err != nil {
+		log.Printf("❌ CreateOrder rejected: %v", err)
+	}
+}
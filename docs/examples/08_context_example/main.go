@@ -146,10 +146,7 @@ func cancelableOperationImpl(ctx context.Context) string {
 // -------------------------------------------- Wrapped Functions (Context-Aware) --------------------------------------------
 
 var (
-	GetUserWithContext = func(ctx context.Context, id string) (*User, error) {
-		builder := aspect.For("GetUserWithContext")
-		return aspect.Wrap1RECtx[string, *User](builder.GetRegistry(), builder.GetFuncKey(), getUserWithContextImpl)(ctx, id)
-	}
+	GetUserWithContext = aspect.BuildWrap1RECtx(aspect.For("GetUserWithContext"), getUserWithContextImpl)
 
 	SlowOperation = func(ctx context.Context, duration time.Duration) error {
 		builder := aspect.For("SlowOperation")
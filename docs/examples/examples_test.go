@@ -0,0 +1,43 @@
+//go:build examples
+
+// Package examples_test guards the example tree: it imports the canonical module path and builds
+// every example. Run it with:
+//
+//	go test -tags examples ./docs/examples/
+package examples_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/seyallius/gosaidno/aspect"
+)
+
+// -------------------------------------------- Tests --------------------------------------------
+
+func TestCanonicalImportPath(t *testing.T) {
+	registry := aspect.NewRegistry()
+
+	var called bool
+	builder := aspect.ForWithRegistry(registry, "Greet").WithBefore(func(c *aspect.Context) error {
+		called = true
+		return nil
+	})
+	greet := aspect.Wrap1RE(builder.GetRegistry(), builder.GetFuncKey(), func(name string) (string, error) {
+		return "hello " + name, nil
+	})
+
+	if got, err := greet("gopher"); got != "hello gopher" || err != nil {
+		t.Errorf("expected 'hello gopher', got %q, %v", got, err)
+	}
+	if !called {
+		t.Error("expected advice added through For to run")
+	}
+}
+
+func TestExamplesCompile(t *testing.T) {
+	out, err := exec.Command("go", "build", "-o", "/dev/null", "./...").CombinedOutput()
+	if err != nil {
+		t.Fatalf("examples do not compile: %v\n%s", err, out)
+	}
+}
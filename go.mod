@@ -1,6 +1,6 @@
 module github.com/seyallius/gosaidno
 
-go 1.25
+go 1.25.0
 
 // All versions with the misspelled module name are retracted
 retract (
@@ -22,3 +22,5 @@ retract (
 	v0.1.0
 	v0.0.0
 )
+
+require golang.org/x/sync v0.20.0